@@ -0,0 +1,15 @@
+// Package analytics computes reports over many parsed standups.
+package analytics
+
+import (
+	"time"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Entry is a parsed standup along with who posted it and on which day.
+type Entry struct {
+	User      string
+	Date      time.Time
+	Statement *parser.Statement
+}
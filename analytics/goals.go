@@ -0,0 +1,96 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Progress is how a goal was worked on by one user during one week.
+type Progress struct {
+	User string
+	Week time.Time // midnight on the Monday the week starts
+	Goal string
+
+	// Days lists the days a Today item was linked to the goal, in order.
+	Days []time.Time
+}
+
+// GoalProgress reports the progress of each goal set in a Goals section,
+// by user and week. Goals carry over from the day they are posted to the
+// end of the week, or until a later Goals section replaces them; Today
+// items of each day are linked to the active goals with parser.LinkGoals.
+//
+// Reports are ordered by user, week, then the order goals were set in.
+// Goals that were never worked on are reported with no days.
+func GoalProgress(entries []Entry) []Progress {
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := entries[order[i]], entries[order[j]]
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		return a.Date.Before(b.Date)
+	})
+
+	var reports []Progress
+	index := map[progressKey]int{} // position of each report in reports
+
+	var user string
+	var week time.Time
+	var active []string
+	for _, i := range order {
+		e := entries[i]
+		if e.Statement == nil {
+			continue
+		}
+
+		if w := weekOf(e.Date); e.User != user || !w.Equal(week) {
+			user, week, active = e.User, w, nil
+		}
+
+		stmt := e.Statement
+		if stmt.Goals.Valid {
+			active = parser.Items(stmt.Goals.Val)
+			for _, goal := range active {
+				key := progressKey{user: user, week: week, goal: goal}
+				if _, ok := index[key]; !ok {
+					index[key] = len(reports)
+					reports = append(reports, Progress{User: user, Week: week, Goal: goal})
+				}
+			}
+		}
+
+		day := startOfDay(e.Date)
+		for _, link := range parser.LinkGoals(active, parser.Items(stmt.Today.Val)) {
+			r := &reports[index[progressKey{user: user, week: week, goal: link.Goal}]]
+			if n := len(r.Days); n == 0 || !r.Days[n-1].Equal(day) {
+				r.Days = append(r.Days, day)
+			}
+		}
+	}
+
+	return reports
+}
+
+// progressKey identifies the progress of one goal.
+type progressKey struct {
+	user string
+	week time.Time
+	goal string
+}
+
+// weekOf returns midnight on the Monday of the week t falls in.
+func weekOf(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
+
+// startOfDay returns midnight of the day t falls on, in t's location.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,35 @@
+package analytics_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/olivoil/standup-parser/analytics"
+)
+
+// Ensure goals carry over within a week and report the days they were worked on.
+func TestGoalProgress(t *testing.T) {
+	monday := time.Date(2018, time.May, 14, 9, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return monday.AddDate(0, 0, n) }
+	midnight := func(n int) time.Time { return time.Date(2018, time.May, 14+n, 0, 0, 0, 0, time.UTC) }
+
+	entries := []analytics.Entry{
+		entry("alice", day(2), "Today: halo deployment\nBlockers: none"),
+		entry("alice", day(0), "Goals:\n- ship halo v2\n- billing migration\nToday: halo release"),
+		entry("alice", day(1), "Today: migrate billing tables, slack"),
+		entry("bob", day(0), "Today: halo"),
+		entry("alice", day(3), "Goals: knod QA\nToday: knod regression tests, halo hotfix"),
+		// A new week starts without goals.
+		entry("alice", day(7), "Today: halo, billing"),
+	}
+
+	exp := []analytics.Progress{
+		{User: "alice", Week: midnight(0), Goal: "ship halo v2", Days: []time.Time{midnight(0), midnight(2)}},
+		{User: "alice", Week: midnight(0), Goal: "billing migration", Days: []time.Time{midnight(1)}},
+		{User: "alice", Week: midnight(0), Goal: "knod QA", Days: []time.Time{midnight(3)}},
+	}
+	if got := analytics.GoalProgress(entries); !reflect.DeepEqual(exp, got) {
+		t.Errorf("progress mismatch:\n  exp=%+v\n  got=%+v", exp, got)
+	}
+}
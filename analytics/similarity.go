@@ -0,0 +1,92 @@
+package analytics
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MinWords is the number of distinct words a standup needs before it is
+// compared; short standups such as "Today: halo" are alike by nature.
+const MinWords = 5
+
+// Match is a pair of near-identical standups.
+type Match struct {
+	A, B  int     // indexes of the entries, A < B
+	Score float64 // similarity between 0 and 1
+}
+
+// Similarity returns the pairs of entries whose content is at least
+// threshold similar, most similar first. Only pairs posted by different
+// users, or by the same user on different days, are compared.
+//
+// Similarity is the Jaccard index of the words of each section, so text
+// moved from Today one day to Yesterday the next does not count.
+func Similarity(entries []Entry, threshold float64) []Match {
+	words := make([]map[string]bool, len(entries))
+	for i, e := range entries {
+		words[i] = sectionWords(e)
+	}
+
+	var matches []Match
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			a, b := entries[i], entries[j]
+			if a.User == b.User && sameDay(a, b) {
+				continue
+			}
+			if len(words[i]) < MinWords || len(words[j]) < MinWords {
+				continue
+			}
+			if score := jaccard(words[i], words[j]); score >= threshold {
+				matches = append(matches, Match{A: i, B: j, Score: score})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
+
+// sectionWords returns the lower-case words of each text section of e,
+// prefixed with the section they appear in.
+func sectionWords(e Entry) map[string]bool {
+	set := map[string]bool{}
+	if e.Statement == nil {
+		return set
+	}
+
+	stmt := e.Statement
+	for section, val := range map[string]string{
+		"yesterday": stmt.Yesterday.Val,
+		"today":     stmt.Today.Val,
+		"goals":     stmt.Goals.Val,
+		"meetings":  stmt.Meetings.Val,
+		"blockers":  stmt.Blockers.Val,
+	} {
+		for _, w := range strings.FieldsFunc(strings.ToLower(val), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			set[section+":"+w] = true
+		}
+	}
+	return set
+}
+
+// jaccard returns the size of the intersection of a and b over the size of their union.
+func jaccard(a, b map[string]bool) float64 {
+	var n int
+	for w := range a {
+		if b[w] {
+			n++
+		}
+	}
+	return float64(n) / float64(len(a)+len(b)-n)
+}
+
+// sameDay is true if a and b were posted on the same calendar day.
+func sameDay(a, b Entry) bool {
+	ay, am, ad := a.Date.Date()
+	by, bm, bd := b.Date.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,48 @@
+package analytics_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olivoil/standup-parser"
+	"github.com/olivoil/standup-parser/analytics"
+)
+
+// Ensure near-identical standups are flagged across users and days.
+func TestSimilarity(t *testing.T) {
+	monday := time.Date(2018, time.May, 14, 9, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	entries := []analytics.Entry{
+		entry("alice", monday, "Yesterday: halo deployment, slack bot\nToday: coomo architecture planning\nBlockers: none"),
+		entry("bob", monday, "Yesterday: halo deployment, slack bot\nToday: coomo architecture planning!\nBlockers: none"),
+		entry("alice", tuesday, "Yesterday: halo deployment, slack bot\nToday: coomo architecture planning\nBlockers: none"),
+		entry("carol", monday, "Yesterday: ibm onboarding docs\nToday: knod release and QA with the client"),
+		entry("alice", monday, "Yesterday: halo deployment, slack bot\nToday: coomo architecture planning\nBlockers: none"),
+		entry("dave", monday, "Today: halo"),
+		entry("erin", monday, "Today: halo"),
+		// Yesterday's Today moved to Yesterday is not a copy.
+		entry("carol", tuesday, "Yesterday: knod release and QA with the client\nToday: ibm onboarding docs"),
+	}
+
+	var got [][2]int
+	for _, m := range analytics.Similarity(entries, 0.8) {
+		if m.Score < 0.8 || m.Score > 1 {
+			t.Errorf("unexpected score %v for %d/%d", m.Score, m.A, m.B)
+		}
+		got = append(got, [2]int{m.A, m.B})
+	}
+
+	exp := [][2]int{{0, 1}, {0, 2}, {1, 2}, {1, 4}, {2, 4}}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("matches mismatch:\n  exp=%v\n  got=%v", exp, got)
+	}
+}
+
+// entry parses s into an Entry.
+func entry(user string, date time.Time, s string) analytics.Entry {
+	stmt, _ := parser.New(strings.NewReader(s)).Parse()
+	return analytics.Entry{User: user, Date: date, Statement: stmt}
+}
@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// oversizedSample is how much of an oversized statement ParseArchive
+// reads, to show in its failure.
+const oversizedSample = 1 << 10
+
+// Span locates one statement within an archive.
+type Span struct {
+	Off int64 // offset of the first byte
+	Len int64 // length in bytes
+}
+
+// IndexArchive scans size bytes of r for lines consisting only of sep,
+// such as "---", and returns the spans of the statements between them.
+// Blank statements are skipped. Only line boundaries are looked at, so
+// indexing is much cheaper than parsing.
+func IndexArchive(r io.ReaderAt, size int64, sep string) ([]Span, error) {
+	if sep == "" {
+		return nil, errors.New("empty separator")
+	}
+
+	var spans []Span
+	var start, off int64
+	blank := true
+	long := false // reading the rest of a line longer than the buffer
+
+	br := bufio.NewReader(io.NewSectionReader(r, 0, size))
+	for {
+		line, err := br.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			// Long lines cannot be separators; keep reading.
+			off += int64(len(line))
+			blank = blank && len(bytes.TrimSpace(line)) == 0
+			long = true
+			continue
+		} else if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		trimmed := bytes.TrimSpace(line)
+		if long {
+			long = false
+			blank = blank && len(trimmed) == 0
+		} else if string(trimmed) == sep {
+			if !blank {
+				spans = append(spans, Span{Off: start, Len: off - start})
+			}
+			start, blank = off+int64(len(line)), true
+		} else if len(trimmed) > 0 {
+			blank = false
+		}
+		off += int64(len(line))
+
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if !blank {
+		spans = append(spans, Span{Off: start, Len: off - start})
+	}
+	return spans, nil
+}
+
+// ParseArchive indexes an archive of statements separated by sep lines,
+// then parses them concurrently with up to workers goroutines, reading
+// each statement directly from r. It returns the same result as
+// ParseBatch over the statements, in archive order, except that the input
+// of an oversized statement is only its first bytes.
+//
+// If workers is zero or less, one goroutine per CPU is used.
+func ParseArchive(r io.ReaderAt, size int64, sep string, workers int, opts ...Option) (*BatchResult, error) {
+	spans, err := IndexArchive(r, size, sep)
+	if err != nil {
+		return nil, err
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	opts = append([]Option{WithMaxSize(DefaultBatchMaxSize)}, opts...)
+	maxSize := int64(New(strings.NewReader(""), opts...).maxSize)
+
+	inputs := make([]string, len(spans))
+	kinds := make([]FailureKind, len(spans))
+	errs := make([]error, len(spans))
+	stmts := make([]*Statement, len(spans))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var readErr error
+	next := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				// Oversized statements, such as a run of statements with a
+				// missing separator, are not read beyond a sample.
+				n := spans[i].Len
+				oversized := maxSize > 0 && n > maxSize
+				if oversized && n > oversizedSample {
+					n = oversizedSample
+				}
+
+				b := make([]byte, n)
+				if _, err := r.ReadAt(b, spans[i].Off); err != nil && err != io.EOF {
+					mu.Lock()
+					readErr = err
+					mu.Unlock()
+					continue
+				}
+				if oversized {
+					for len(b) > 0 && !utf8.Valid(b) {
+						b = b[:len(b)-1] // a rune cut by the sample
+					}
+					inputs[i], kinds[i], errs[i] = string(b), FailOversized, ErrOversized
+					continue
+				}
+
+				input := string(b)
+				stmts[i], kinds[i], errs[i] = parseOne(input, opts)
+				if kinds[i] != "" {
+					inputs[i] = input // only kept for Failure.Input
+				}
+			}
+		}()
+	}
+	for i := range spans {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	res := &BatchResult{Statements: stmts, Failures: map[FailureKind][]Failure{}}
+	for i, kind := range kinds {
+		if kind != "" {
+			res.Failures[kind] = append(res.Failures[kind], Failure{Index: i, Input: inputs[i], Err: errs[i]})
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,157 @@
+package parser_test
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure statement boundaries are indexed on separator lines.
+func TestIndexArchive(t *testing.T) {
+	archive := "Today: halo\n---\n\n---\nYesterday: ibm\nToday: coomo\n  ---  \nLP: done"
+	r := strings.NewReader(archive)
+
+	spans, err := parser.IndexArchive(r, r.Size(), "---")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, s := range spans {
+		got = append(got, archive[s.Off:s.Off+s.Len])
+	}
+	exp := []string{"Today: halo\n", "Yesterday: ibm\nToday: coomo\n", "LP: done"}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("spans mismatch:\n  exp=%q\n  got=%q", exp, got)
+	}
+}
+
+// Ensure the end of a line longer than the read buffer is not taken
+// for a separator.
+func TestIndexArchive_LongLine(t *testing.T) {
+	archive := "Today: " + strings.Repeat("x", 4096-len("Today: ")) + "---\nLP: done\n---\nToday: halo"
+	r := strings.NewReader(archive)
+
+	spans, err := parser.IndexArchive(r, r.Size(), "---")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 2 || spans[0].Off != 0 || spans[1].Off != int64(len(archive)-len("Today: halo")) {
+		t.Errorf("unexpected spans: %+v", spans)
+	}
+}
+
+// Ensure archives parse concurrently into the same result as a batch.
+func TestParseArchive(t *testing.T) {
+	var inputs []string
+	for i := 0; i < 200; i++ {
+		switch i % 4 {
+		case 0:
+			inputs = append(inputs, "Yesterday: ibm\nToday: halo\nLP: up to date\n")
+		case 1:
+			inputs = append(inputs, "Today: coomo\nJira: not yet\n")
+		case 2:
+			inputs = append(inputs, "Today: knod\nLP: maybe\n")
+		case 3:
+			inputs = append(inputs, "Friday: slack\n- meetings: huddle\n")
+		}
+	}
+	archive := strings.Join(inputs, "---\n")
+	r := strings.NewReader(archive)
+
+	res, err := parser.ParseArchive(r, r.Size(), "---", 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := parser.ParseBatch(inputs)
+	if !reflect.DeepEqual(exp.Statements, res.Statements) {
+		t.Errorf("statements mismatch")
+	}
+	if len(res.Failures[parser.FailAmbiguous]) != 50 {
+		t.Errorf("expected 50 ambiguous statements, got %d", len(res.Failures[parser.FailAmbiguous]))
+	}
+	if !reflect.DeepEqual(exp.Failures, res.Failures) {
+		t.Errorf("failures mismatch:\n  exp=%v\n  got=%v", exp.Failures, res.Failures)
+	}
+}
+
+// Ensure options without aliases can be shared by the workers; run with -race.
+func TestParseArchive_SharedOptions(t *testing.T) {
+	archive := strings.Repeat("Timesheet: yes\nBlockers: waiting on design\n---\n", 50)
+	r := strings.NewReader(archive)
+
+	res, err := parser.ParseArchive(r, r.Size(), "---", 8,
+		parser.WithCheck("timesheet"),
+		parser.WithRoute(parser.Route{Name: "design", Kind: "team"}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, stmt := range res.Statements {
+		if stmt == nil || !stmt.Checks["timesheet"].Val || len(stmt.Blockers.RoutedTo) != 1 {
+			t.Fatalf("statement %d mismatch: %+v", i, stmt)
+		}
+	}
+
+	// Apply fresh options from goroutines started at once, as the first
+	// statements of an archive are.
+	opts := []parser.Option{parser.WithCheck("timesheet"), parser.WithRoute(parser.Route{Name: "design"})}
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			parser.New(strings.NewReader("Timesheet: yes"), opts...).Parse()
+		}()
+	}
+	close(start)
+	wg.Wait()
+}
+
+// Ensure oversized statements are reported without being read in full.
+func TestParseArchive_Oversized(t *testing.T) {
+	archive := "Today: halo\n---\n" + strings.Repeat("Today: a missing separator\n", 4000) + "---\nToday: coomo\n"
+	r := &maxReaderAt{r: strings.NewReader(archive)}
+
+	res, err := parser.ParseArchive(r, int64(len(archive)), "---", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := res.Failures[parser.FailOversized]
+	if len(fs) != 1 || fs[0].Index != 1 || fs[0].Err != parser.ErrOversized {
+		t.Fatalf("unexpected failures: %v", res.Failures)
+	}
+	if !strings.HasPrefix(fs[0].Input, "Today: a missing separator\n") || len(fs[0].Input) > 1024 {
+		t.Errorf("expected a short sample of the input, got %d bytes", len(fs[0].Input))
+	}
+	if r.max > parser.DefaultBatchMaxSize {
+		t.Errorf("expected reads of at most %d bytes, got %d", parser.DefaultBatchMaxSize, r.max)
+	}
+	if res.Statements[0] == nil || res.Statements[2] == nil || res.Statements[1] != nil {
+		t.Errorf("unexpected statements: %v", res.Statements)
+	}
+}
+
+// maxReaderAt records the largest read made through it.
+type maxReaderAt struct {
+	r   io.ReaderAt
+	mu  sync.Mutex
+	max int
+}
+
+func (r *maxReaderAt) ReadAt(b []byte, off int64) (int, error) {
+	r.mu.Lock()
+	if len(b) > r.max {
+		r.max = len(b)
+	}
+	r.mu.Unlock()
+	return r.r.ReadAt(b, off)
+}
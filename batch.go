@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrOversized is returned by Parse when the input exceeds WithMaxSize.
+var ErrOversized = errors.New("input too large")
+
+// DefaultBatchMaxSize is the input size above which ParseBatch reports an
+// input as oversized, unless WithMaxSize says otherwise.
+const DefaultBatchMaxSize = 64 << 10
+
+// FailureKind groups batch failures by cause.
+type FailureKind string
+
+const (
+	FailEmpty     FailureKind = "empty"     // blank input
+	FailAmbiguous FailureKind = "ambiguous" // a check-in could not be read as yes or no
+	FailOversized FailureKind = "oversized" // input larger than the maximum size
+	FailPanic     FailureKind = "panic"     // the parser panicked and recovered
+	FailInvalid   FailureKind = "invalid"   // Parse returned another error
+)
+
+// Failure is an input of a batch that did not parse cleanly.
+type Failure struct {
+	Index int    // index of the input in the batch
+	Input string // the input itself, or its start for ParseArchive if oversized
+	Err   error
+}
+
+// BatchResult is the outcome of ParseBatch. It is an error listing the
+// failures by kind, with a few sample inputs for each.
+type BatchResult struct {
+	// Statements holds the statement parsed from each input, or nil if
+	// it could not be parsed. Ambiguous statements are kept.
+	Statements []*Statement
+
+	// Failures holds the failed inputs, by kind.
+	Failures map[FailureKind][]Failure
+}
+
+// batchSamples is the number of sample inputs shown per kind of failure.
+const batchSamples = 3
+
+// ParseBatch parses each input with opts, recovering from panics, and
+// groups the inputs that did not parse cleanly by kind.
+func ParseBatch(inputs []string, opts ...Option) *BatchResult {
+	opts = append([]Option{WithMaxSize(DefaultBatchMaxSize)}, opts...)
+	res := &BatchResult{
+		Statements: make([]*Statement, len(inputs)),
+		Failures:   map[FailureKind][]Failure{},
+	}
+
+	for i, input := range inputs {
+		stmt, kind, err := parseOne(input, opts)
+		res.Statements[i] = stmt
+		if kind != "" {
+			res.Failures[kind] = append(res.Failures[kind], Failure{Index: i, Input: input, Err: err})
+		}
+	}
+	return res
+}
+
+// parseOne parses input and classifies its failure, if any.
+func parseOne(input string, opts []Option) (stmt *Statement, kind FailureKind, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			stmt, kind, err = nil, FailPanic, fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if strings.TrimSpace(input) == "" {
+		return nil, FailEmpty, errors.New("empty input")
+	}
+
+	stmt, err = New(strings.NewReader(input), opts...).Parse()
+	if err == ErrOversized {
+		return nil, FailOversized, err
+	} else if err != nil {
+		return nil, FailInvalid, err
+	}
+
+	for _, f := range stmt.checks() {
+		if f.Key != "" && !f.Valid {
+			return stmt, FailAmbiguous, fmt.Errorf("%s: cannot tell yes or no from %q", f.Key, f.Lit)
+		}
+	}
+	return stmt, "", nil
+}
+
+// checks returns the boolean fields of the statement.
+func (s *Statement) checks() []BoolField {
+	fs := []BoolField{s.LP, s.Jira}
+	for _, name := range sortedNames(s.Checks) {
+		fs = append(fs, s.Checks[name])
+	}
+	return fs
+}
+
+// sortedNames returns the names of the declared checks in order.
+func sortedNames(m map[string]BoolField) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Err returns the result as an error if any input failed, or nil.
+func (r *BatchResult) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	return r
+}
+
+// Error summarizes the failures by kind, with sample inputs.
+func (r *BatchResult) Error() string {
+	kinds := make([]string, 0, len(r.Failures))
+	total := 0
+	for kind, fs := range r.Failures {
+		kinds = append(kinds, string(kind))
+		total += len(fs)
+	}
+	sort.Strings(kinds)
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d of %d inputs failed:", total, len(r.Statements))
+	for _, kind := range kinds {
+		fs := r.Failures[FailureKind(kind)]
+		fmt.Fprintf(&buf, "\n  %s (%d):", kind, len(fs))
+		for i, f := range fs {
+			if i == batchSamples {
+				fmt.Fprintf(&buf, "\n    ...")
+				break
+			}
+			fmt.Fprintf(&buf, "\n    #%d %q: %s", f.Index, sample(f.Input), f.Err)
+		}
+	}
+	return buf.String()
+}
+
+// sample shortens an input to fit on a summary line.
+func sample(s string) string {
+	if r := []rune(s); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return s
+}
+
+// limitReader reads from r until more than n bytes were read.
+type limitReader struct {
+	r        io.Reader
+	n        int
+	exceeded bool
+}
+
+// Read reads from the underlying reader, failing once the limit is exceeded.
+func (l *limitReader) Read(b []byte) (int, error) {
+	if l.n < 0 {
+		l.exceeded = true
+		return 0, ErrOversized
+	}
+	if len(b) > l.n+1 {
+		b = b[:l.n+1]
+	}
+	n, err := l.r.Read(b)
+	l.n -= n
+	if l.n < 0 {
+		l.exceeded = true
+		return 0, ErrOversized
+	}
+	return n, err
+}
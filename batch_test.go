@@ -0,0 +1,102 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure batch failures are grouped by kind and summarized.
+func TestParseBatch(t *testing.T) {
+	inputs := []string{
+		"Today: halo\nLP: up to date",
+		"  \n",
+		"LP: not updated yet, done soon",
+		strings.Repeat("Today: halo\n", 100),
+		"Yesterday: ibm",
+		"",
+		"Timesheet: maybe",
+	}
+
+	// A calendar that panics stands in for a bug in the parser.
+	boom := parser.CalendarFunc(func(day time.Time) bool {
+		if day.Weekday() == time.Friday {
+			panic("boom")
+		}
+		return false
+	})
+
+	res := parser.ParseBatch(inputs,
+		parser.WithMaxSize(256),
+		parser.WithReferenceTime(time.Date(2018, time.May, 14, 9, 0, 0, 0, time.UTC)),
+		parser.WithCalendar(boom),
+		parser.WithCheck("timesheet"),
+	)
+
+	exp := map[parser.FailureKind][]int{
+		parser.FailEmpty:     {1, 5},
+		parser.FailAmbiguous: {2, 6},
+		parser.FailOversized: {3},
+		parser.FailPanic:     {4},
+	}
+	if len(res.Failures) != len(exp) {
+		t.Errorf("failure kinds mismatch: exp=%v got=%v", exp, res.Failures)
+	}
+	for kind, indexes := range exp {
+		fs := res.Failures[kind]
+		if len(fs) != len(indexes) {
+			t.Errorf("%s: exp=%v got=%v", kind, indexes, fs)
+			continue
+		}
+		for i, f := range fs {
+			if f.Index != indexes[i] || f.Input != inputs[f.Index] {
+				t.Errorf("%s: failure %d mismatch: exp=#%d got=#%d", kind, i, indexes[i], f.Index)
+			}
+		}
+	}
+
+	if res.Statements[0] == nil || res.Statements[0].Today.Val != "halo" {
+		t.Errorf("expected first input to be parsed, got %+v", res.Statements[0])
+	}
+	if res.Statements[2] == nil {
+		t.Errorf("expected ambiguous statement to be kept")
+	}
+	if res.Statements[3] != nil || res.Statements[4] != nil {
+		t.Errorf("expected no statement for oversized and panicking inputs")
+	}
+
+	msg := errstring(res.Err())
+	for _, s := range []string{
+		"6 of 7 inputs failed:",
+		"  ambiguous (2):\n    #2 \"LP: not updated yet, done soon\": LP: cannot tell yes or no from \"not updated yet, done soon\"",
+		"  oversized (1):\n    #3 \"Today: halo\\nToday: halo\\nToday: halo\\nToday: halo\\nToday: ha...\": input too large",
+		"  panic (1):\n    #4 \"Yesterday: ibm\": panic: boom",
+	} {
+		if !strings.Contains(msg, s) {
+			t.Errorf("expected summary to contain %q, got:\n%s", s, msg)
+		}
+	}
+}
+
+// Ensure a clean batch has no error.
+func TestParseBatch_NoFailures(t *testing.T) {
+	res := parser.ParseBatch([]string{"Today: halo", "Yesterday: ibm"})
+	if err := res.Err(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+// Ensure Parse enforces the maximum size.
+func TestParser_MaxSize(t *testing.T) {
+	_, err := parser.New(strings.NewReader("Today: halo"), parser.WithMaxSize(5)).Parse()
+	if err != parser.ErrOversized {
+		t.Errorf("expected ErrOversized, got %v", err)
+	}
+
+	_, err = parser.New(strings.NewReader("Today: halo"), parser.WithMaxSize(11), parser.WithRepair()).Parse()
+	if err != nil {
+		t.Errorf("unexpected error at exactly the maximum size: %v", err)
+	}
+}
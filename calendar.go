@@ -0,0 +1,81 @@
+package parser
+
+import "time"
+
+// Calendar reports the days off a team observes on top of weekends.
+type Calendar interface {
+	IsHoliday(day time.Time) bool
+}
+
+// CalendarFunc adapts an ordinary function to a Calendar.
+type CalendarFunc func(day time.Time) bool
+
+// IsHoliday calls f(day).
+func (f CalendarFunc) IsHoliday(day time.Time) bool { return f(day) }
+
+// Holidays returns a Calendar of fixed days, such as company days off.
+func Holidays(days ...time.Time) Calendar {
+	set := map[[3]int]bool{}
+	for _, d := range days {
+		set[dateKey(d)] = true
+	}
+	return CalendarFunc(func(day time.Time) bool { return set[dateKey(day)] })
+}
+
+// USHolidays is the calendar of US federal holidays, including the
+// Friday or Monday they are observed on when they fall on a weekend.
+var USHolidays Calendar = CalendarFunc(isUSHoliday)
+
+// isUSHoliday is true if day is a US federal holiday or observed as one.
+func isUSHoliday(day time.Time) bool {
+	y, m, d := day.Date()
+	wd := day.Weekday()
+
+	// Holidays on a fixed date move to Friday or Monday when they fall on a weekend.
+	fixed := func(month time.Month, date int) bool {
+		h := time.Date(y, month, date, 0, 0, 0, 0, day.Location())
+		switch h.Weekday() {
+		case time.Saturday:
+			h = h.AddDate(0, 0, -1)
+		case time.Sunday:
+			h = h.AddDate(0, 0, 1)
+		}
+		return dateKey(h) == dateKey(day)
+	}
+
+	// nth is true if day is the nth weekday of month, or the last one if n < 0.
+	nth := func(month time.Month, weekday time.Weekday, n int) bool {
+		if m != month || wd != weekday {
+			return false
+		}
+		if n < 0 {
+			return d+7 > daysIn(month, y)
+		}
+		return (d-1)/7 == n-1
+	}
+
+	return fixed(time.January, 1) ||
+		// New Year's Day observed on the previous Friday falls in December.
+		(m == time.December && d == 31 && wd == time.Friday) ||
+		nth(time.January, time.Monday, 3) || // Martin Luther King Jr. Day
+		nth(time.February, time.Monday, 3) || // Washington's Birthday
+		nth(time.May, time.Monday, -1) || // Memorial Day
+		(y >= 2021 && fixed(time.June, 19)) || // Juneteenth
+		fixed(time.July, 4) ||
+		nth(time.September, time.Monday, 1) || // Labor Day
+		nth(time.October, time.Monday, 2) || // Columbus Day
+		fixed(time.November, 11) || // Veterans Day
+		nth(time.November, time.Thursday, 4) || // Thanksgiving Day
+		fixed(time.December, 25)
+}
+
+// daysIn returns the number of days in month of year.
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// dateKey identifies the calendar day of t, regardless of time and location.
+func dateKey(t time.Time) [3]int {
+	y, m, d := t.Date()
+	return [3]int{y, int(m), d}
+}
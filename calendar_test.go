@@ -0,0 +1,46 @@
+package parser_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure US federal holidays are recognized, including observed days.
+func TestUSHolidays_IsHoliday(t *testing.T) {
+	var tests = []struct {
+		day time.Time
+		exp bool
+	}{
+		{day: date(2018, time.January, 1), exp: true},
+		{day: date(2018, time.January, 15), exp: true},   // MLK Day
+		{day: date(2018, time.May, 28), exp: true},       // Memorial Day
+		{day: date(2018, time.May, 21), exp: false},      // a Monday in May
+		{day: date(2018, time.November, 22), exp: true},  // Thanksgiving
+		{day: date(2018, time.November, 29), exp: false}, // a Thursday after it
+		{day: date(2020, time.July, 3), exp: true},       // July 4th observed
+		{day: date(2021, time.December, 31), exp: true},  // New Year's Day observed
+		{day: date(2022, time.June, 20), exp: true},      // Juneteenth observed
+		{day: date(2018, time.June, 19), exp: false},     // before Juneteenth
+		{day: date(2018, time.March, 14), exp: false},
+	}
+
+	for i, tt := range tests {
+		if got := parser.USHolidays.IsHoliday(tt.day); got != tt.exp {
+			t.Errorf("%d. %s: exp=%v got=%v", i, tt.day.Format("2006-01-02"), tt.exp, got)
+		}
+	}
+}
+
+// Ensure fixed day calendars match days regardless of time of day.
+func TestHolidays_IsHoliday(t *testing.T) {
+	c := parser.Holidays(date(2018, time.August, 17))
+
+	if !c.IsHoliday(date(2018, time.August, 17).Add(15 * time.Hour)) {
+		t.Errorf("expected company day off to be a holiday")
+	}
+	if c.IsHoliday(date(2018, time.August, 16)) {
+		t.Errorf("expected the day before not to be a holiday")
+	}
+}
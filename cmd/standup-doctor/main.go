@@ -0,0 +1,293 @@
+// Command standup-doctor checks a parser configuration before it is
+// rolled out. It reports problems with the configuration, then runs the
+// configured parser over a built-in suite of representative standups and
+// over a team's own corpus, and reports how well they are recognized.
+//
+// Usage:
+//
+//	standup-doctor [-corpus dir] [-check name=Alias,...] [-number name=Alias,...]
+//	               [-enum name=value|value=Alias,...] [-implicit today|yesterday|none]
+//	               [-transcript] [-repair]
+//
+// The corpus directory holds one standup per .txt file. The exit status
+// is 1 if the configuration has problems or the built-in suite fails to
+// parse, 2 on usage errors. Standups rejected for text before the first
+// keyword, as -implicit none does, are reported but do not fail the suite.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/olivoil/standup-parser"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with args and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	var opts []parser.Option
+
+	fs := flag.NewFlagSet("standup-doctor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	corpus := fs.String("corpus", "", "directory of .txt standups to check")
+	implicit := fs.String("implicit", "today", "section of text before the first keyword: today, yesterday or none")
+	transcript := fs.Bool("transcript", false, "parse input as voice transcripts")
+	repair := fs.Bool("repair", false, "repair the encoding of input")
+	fs.Var(declare(&opts, parser.WithCheck), "check", "declare a check-in section, as name=Alias,...")
+	fs.Var(declare(&opts, parser.WithNumber), "number", "declare a numeric section, as name=Alias,...")
+	fs.Var(enumFlag{&opts}, "enum", "declare an enum section, as name=value|value=Alias,...")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	switch *implicit {
+	case "today":
+	case "yesterday":
+		opts = append(opts, parser.WithImplicitSection(parser.YESTERDAY))
+	case "none":
+		opts = append(opts, parser.WithoutImplicitSection())
+	default:
+		fmt.Fprintf(stderr, "invalid -implicit %q\n", *implicit)
+		return 2
+	}
+	if *transcript {
+		opts = append(opts, parser.WithTranscript())
+	}
+	if *repair {
+		opts = append(opts, parser.WithRepair())
+	}
+
+	status := 0
+	fmt.Fprintf(stdout, "standup-doctor, parser %s\n\n", parser.Version())
+
+	fmt.Fprintln(stdout, "Configuration")
+	if problems := parser.Lint(opts...); len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintf(stdout, "  problem: %s\n", p)
+		}
+		status = 1
+	} else {
+		fmt.Fprintln(stdout, "  ok")
+	}
+
+	// Standups rejected for text before the first keyword are reported on
+	// their own: they are expected when a team turns off the implicit section.
+	r := check(suite, opts)
+	r.write(stdout, "Built-in suite")
+	if r.errors > 0 {
+		status = 1
+	}
+
+	if *corpus != "" {
+		inputs, err := readCorpus(*corpus)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		check(inputs, opts).write(stdout, "Corpus "+*corpus)
+	}
+
+	return status
+}
+
+// report holds the recognition statistics of a set of standups.
+type report struct {
+	total      int
+	recognized int            // standups with at least one keyword
+	errors     int            // standups Parse returned an error for
+	rejected   int            // standups rejected for text before the first keyword
+	sections   map[string]int // standups each section was found in
+	checks     int            // check-ins found
+	ambiguous  int            // check-ins that are neither yes nor no
+}
+
+// check parses each input with opts and gathers statistics.
+func check(inputs []string, opts []parser.Option) report {
+	r := report{total: len(inputs), sections: map[string]int{}}
+
+	res := parser.ParseBatch(inputs, opts...)
+	for _, fs := range res.Failures {
+		for _, f := range fs {
+			if res.Statements[f.Index] != nil {
+				continue
+			}
+			if f.Err != parser.ErrOversized && implicitOnly(f.Input, opts) {
+				r.rejected++
+			} else {
+				r.errors++
+			}
+		}
+	}
+
+	for _, stmt := range res.Statements {
+		if stmt == nil {
+			continue
+		}
+
+		found := keyed(stmt)
+		if len(found) > 0 {
+			r.recognized++
+		}
+		for _, name := range found {
+			r.sections[name]++
+		}
+
+		bools := []parser.BoolField{stmt.LP, stmt.Jira}
+		for _, f := range stmt.Checks {
+			bools = append(bools, f)
+		}
+		for _, f := range bools {
+			if f.Key == "" {
+				continue
+			}
+			r.checks++
+			if !f.Valid {
+				r.ambiguous++
+			}
+		}
+	}
+
+	return r
+}
+
+// implicitOnly is true if input only fails to parse with opts because it
+// has text before the first keyword, i.e. it parses once that text is
+// read as Today.
+func implicitOnly(input string, opts []parser.Option) bool {
+	opts = append(opts[:len(opts):len(opts)], parser.WithImplicitSection(parser.TODAY))
+	_, err := parser.New(strings.NewReader(input), opts...).Parse()
+	return err == nil
+}
+
+// keyed returns the names of the sections of stmt introduced by a keyword.
+func keyed(stmt *parser.Statement) []string {
+	var names []string
+	for name, key := range map[string]string{
+		"yesterday": stmt.Yesterday.Key,
+		"today":     stmt.Today.Key,
+		"goals":     stmt.Goals.Key,
+		"meetings":  stmt.Meetings.Key,
+		"blockers":  stmt.Blockers.Key,
+		"ooo":       stmt.OOO.Key,
+		"lp":        stmt.LP.Key,
+		"jira":      stmt.Jira.Key,
+	} {
+		if key != "" {
+			names = append(names, name)
+		}
+	}
+	for name := range stmt.Checks {
+		names = append(names, "checks."+name)
+	}
+	for name := range stmt.Numbers {
+		names = append(names, "numbers."+name)
+	}
+	for name := range stmt.Enums {
+		names = append(names, "enums."+name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// write prints the report under title.
+func (r report) write(w io.Writer, title string) {
+	fmt.Fprintf(w, "\n%s (%d standups)\n", title, r.total)
+	fmt.Fprintf(w, "  recognized:       %s\n", ratio(r.recognized, r.total))
+	fmt.Fprintf(w, "  errors:           %s\n", ratio(r.errors, r.total))
+	fmt.Fprintf(w, "  rejected text:    %s\n", ratio(r.rejected, r.total))
+	fmt.Fprintf(w, "  ambiguous checks: %s\n", ratio(r.ambiguous, r.checks))
+
+	names := make([]string, 0, len(r.sections))
+	for name := range r.sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-18s%d\n", name+":", r.sections[name])
+	}
+}
+
+// ratio formats n out of total along with its percentage.
+func ratio(n, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%.0f%%)", n, total, 100*float64(n)/float64(total))
+}
+
+// readCorpus reads every .txt file of dir.
+func readCorpus(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	inputs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, string(b))
+	}
+	return inputs, nil
+}
+
+// declareFlag declares a section for each name=Alias,... value.
+type declareFlag struct {
+	opts    *[]parser.Option
+	declare func(name string, aliases ...string) parser.Option
+}
+
+// declare returns a flag declaring sections with fn.
+func declare(opts *[]parser.Option, fn func(string, ...string) parser.Option) declareFlag {
+	return declareFlag{opts: opts, declare: fn}
+}
+
+func (f declareFlag) String() string { return "" }
+
+func (f declareFlag) Set(v string) error {
+	name, aliases := splitDeclaration(v)
+	*f.opts = append(*f.opts, f.declare(name, aliases...))
+	return nil
+}
+
+// enumFlag declares an enum section for each name=value|value=Alias,... value.
+type enumFlag struct {
+	opts *[]parser.Option
+}
+
+func (f enumFlag) String() string { return "" }
+
+func (f enumFlag) Set(v string) error {
+	parts := strings.SplitN(v, "=", 3)
+	if len(parts) < 2 {
+		return fmt.Errorf("expected name=value|value, got %q", v)
+	}
+
+	var aliases []string
+	if len(parts) == 3 {
+		aliases = strings.Split(parts[2], ",")
+	}
+	*f.opts = append(*f.opts, parser.WithEnum(parts[0], strings.Split(parts[1], "|"), aliases...))
+	return nil
+}
+
+// splitDeclaration splits name=Alias,... into the name and its aliases.
+func splitDeclaration(v string) (string, []string) {
+	i := strings.Index(v, "=")
+	if i < 0 {
+		return v, nil
+	}
+	return v[:i], strings.Split(v[i+1:], ",")
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Ensure the default configuration passes the built-in suite.
+func TestRun_Default(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if status := run(nil, &stdout, &stderr); status != 0 {
+		t.Fatalf("unexpected status %d:\n%s%s", status, stdout.String(), stderr.String())
+	}
+
+	for _, s := range []string{
+		"Configuration\n  ok\n",
+		"Built-in suite (9 standups)\n  recognized:       9/9 (100%)\n  errors:           0/9 (0%)\n",
+	} {
+		if !strings.Contains(stdout.String(), s) {
+			t.Errorf("expected output to contain %q, got:\n%s", s, stdout.String())
+		}
+	}
+}
+
+// Ensure configuration problems and corpus statistics are reported.
+func TestRun_Corpus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "standup-doctor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, s := range map[string]string{
+		"a.txt": "Today: halo\nTimesheet: yes",
+		"b.txt": "Today: halo\nTimesheet: maybe",
+		"c.txt": "just some text",
+		"d.md":  "ignored",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"-corpus", dir, "-check", "timesheet=Timesheet,Hours", "-enum", "mood=green|red"}, &stdout, &stderr)
+	if status != 1 {
+		t.Errorf("expected status 1, got %d", status)
+	}
+
+	for _, s := range []string{
+		`problem: keyword "hours" of checks.timesheet is unreachable: it is a built-in keyword for lp`,
+		"Corpus " + dir + " (3 standups)\n  recognized:       2/3 (67%)\n  errors:           0/3 (0%)\n  rejected text:    0/3 (0%)\n  ambiguous checks: 1/2 (50%)\n",
+		"  checks.timesheet: 2\n",
+	} {
+		if !strings.Contains(stdout.String(), s) {
+			t.Errorf("expected output to contain %q, got:\n%s", s, stdout.String())
+		}
+	}
+}
+
+// Ensure text rejected before the first keyword is reported on its own,
+// without failing a configuration that rejects it on purpose.
+func TestRun_Implicit(t *testing.T) {
+	var tests = map[string]struct {
+		args     []string
+		status   int
+		errors   string
+		rejected string
+	}{
+		"yesterday": {args: []string{"-implicit", "yesterday"}, status: 0, errors: "0/9 (0%)", rejected: "0/9 (0%)"},
+		"none":      {args: []string{"-implicit", "none"}, status: 0, errors: "0/9 (0%)", rejected: "1/9 (11%)"},
+		"invalid":   {args: []string{"-implicit", "sometimes"}, status: 2},
+	}
+
+	for label, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if status := run(tt.args, &stdout, &stderr); status != tt.status {
+			t.Errorf("[%v] status mismatch: exp=%d got=%d", label, tt.status, status)
+		}
+		if tt.errors == "" {
+			continue
+		}
+		for _, s := range []string{
+			"errors:           " + tt.errors + "\n",
+			"rejected text:    " + tt.rejected + "\n",
+		} {
+			if !strings.Contains(stdout.String(), s) {
+				t.Errorf("[%v] expected output to contain %q, got:\n%s", label, s, stdout.String())
+			}
+		}
+	}
+}
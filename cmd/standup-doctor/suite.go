@@ -0,0 +1,55 @@
+package main
+
+// suite is a set of representative standups, as posted by real teams.
+var suite = []string{
+	`Friday: yourtrainer, halo, it's your birthday
+Today:
+  - halo: finish deployment?
+  - yourtrainer: last issues
+  - coomo: architecture planning
+  - meetings: none
+  - blockers: none
+LP: up to date
+Jira: not yet
+`,
+	`Friday: NewCo, Knod, Solitaire
+Today:
+  - Possibly NewCo, QA needs
+  -client revisions
+LP: up to date
+`,
+	`Friday: IBM, CooMo
+Today: CooMo
+time: current
+`,
+	`Today: Meetings & Coomo
+Friday: ACN
+LP: updated
+`,
+	`Previously:
+- Vacation
+Today:
+- Catch Up
+- Bechtel
+- meetings:  Chris Hearn, PM Team
+LP: updated
+`,
+	`Yesterday: ibm, slack
+Today: halo
+Blockers: waiting on design
+Jira: up to date
+`,
+	`*Yesterday*: halo release notes
+*Today*: coomo
+*Meetings*: huddle, UX w/ John
+*LP*: done
+`,
+	`Last Friday: knod QA
+Today: knod release
+OOO: next Thursday
+`,
+	`halo, coomo
+Today: ibm
+LP: done
+`,
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Correction replaces the value of one field of a Statement with a new
+// raw value, as typed by the person reviewing it.
+type Correction struct {
+	// Field is the JSON name of the field, e.g. "today" or "lp". Declared
+	// sections are addressed as "checks.<name>", "numbers.<name>" or "enums.<name>".
+	Field string    `json:"field"`
+	Raw   string    `json:"raw"`
+	By    string    `json:"by"`
+	At    time.Time `json:"at"`
+}
+
+// Apply returns a copy of the statement with the correction applied and
+// recorded in Corrections. The raw value is parsed like the value of the
+// original section; relative dates are resolved against c.At, without
+// the calendar the statement was parsed with. The date of a Yesterday
+// section named by its key, as in "Friday:", is kept.
+//
+// An empty raw value clears the field. A value that does not parse for
+// the field, or an unknown field, is an error. Declared sections can only
+// be corrected if the statement has them.
+//
+// Correcting Blockers clears its routes, since the directory they were
+// resolved against is not known here. To route the corrected value, parse
+// it with ParseSection("Blockers") and the same WithRoute options.
+// Likewise, correcting Today or Goals links Today to the goals of the
+// statement again, and clears the links to goals carried over with WithGoals.
+func (s *Statement) Apply(c Correction) (*Statement, error) {
+	stmt := s.clone()
+	raw := splitAndTrimSpace([]string{c.Raw})
+
+	name, sub := c.Field, ""
+	if i := strings.Index(c.Field, "."); i >= 0 {
+		name, sub = c.Field[:i], c.Field[i+1:]
+	}
+
+	var valid bool
+	switch name {
+	case "yesterday":
+		valid = correctString(&stmt.Yesterday, raw)
+		if _, byKey := (&Parser{}).resolvePast(stmt.Yesterday.Key, ""); !byKey {
+			stmt.Yesterday.Date = nil
+			if !c.At.IsZero() {
+				if day, ok := (&Parser{ref: c.At}).resolvePast(stmt.Yesterday.Key, raw); ok {
+					stmt.Yesterday.Date = &day
+				}
+			}
+		}
+	case "today":
+		valid = correctString(&stmt.Today, raw)
+		(&Parser{}).linkGoals(stmt)
+	case "meetings":
+		valid = correctString(&stmt.Meetings, raw)
+	case "blockers":
+		valid = correctString(&stmt.Blockers, raw)
+		stmt.Blockers.RoutedTo = nil
+	case "goals":
+		valid = correctString(&stmt.Goals, raw)
+		(&Parser{}).linkGoals(stmt)
+	case "ooo":
+		stmt.OOO.Lit, stmt.OOO.From, stmt.OOO.To, stmt.OOO.Valid = raw, time.Time{}, time.Time{}, false
+		if !c.At.IsZero() {
+			from, to, err := resolveRange(raw, c.At)
+			stmt.OOO.From, stmt.OOO.To, stmt.OOO.Valid = from, to, err == nil
+		}
+		valid = stmt.OOO.Valid
+	case "lp":
+		valid = correctBool(&stmt.LP, raw)
+	case "jira":
+		valid = correctBool(&stmt.Jira, raw)
+	case "checks":
+		f, ok := stmt.Checks[sub]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", c.Field)
+		}
+		valid = correctBool(&f, raw)
+		stmt.Checks[sub] = f
+	case "numbers":
+		f, ok := stmt.Numbers[sub]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", c.Field)
+		}
+		val, unit, err := parseNumber(raw)
+		f.Val, f.Unit, f.Lit, f.Valid = val, unit, raw, err == nil
+		stmt.Numbers[sub] = f
+		valid = f.Valid
+	case "enums":
+		f, ok := stmt.Enums[sub]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", c.Field)
+		}
+		val, err := matchEnum(raw, f.Allowed)
+		f.Val, f.Lit, f.Valid = val, raw, err == nil
+		stmt.Enums[sub] = f
+		valid = f.Valid
+	default:
+		return nil, fmt.Errorf("unknown field %q", c.Field)
+	}
+
+	if !valid && raw != "" {
+		return nil, fmt.Errorf("%s: invalid value %q", c.Field, raw)
+	}
+
+	stmt.Corrections = append(stmt.Corrections, c)
+	return stmt, nil
+}
+
+// correctString replaces the value of f, keeping its key.
+func correctString(f *StringField, raw string) bool {
+	f.Val, f.Valid = raw, raw != ""
+	return f.Valid
+}
+
+// correctBool replaces the value of f, keeping its key.
+func correctBool(f *BoolField, raw string) bool {
+	val, err := isPositive(raw)
+	f.Val, f.Lit, f.Valid = val, raw, err == nil
+	return f.Valid
+}
+
+// clone returns a copy of the statement that shares no maps or slices with it.
+func (s *Statement) clone() *Statement {
+	stmt := *s
+
+	if s.Checks != nil {
+		stmt.Checks = make(map[string]BoolField, len(s.Checks))
+		for k, v := range s.Checks {
+			stmt.Checks[k] = v
+		}
+	}
+	if s.Numbers != nil {
+		stmt.Numbers = make(map[string]NumberField, len(s.Numbers))
+		for k, v := range s.Numbers {
+			stmt.Numbers[k] = v
+		}
+	}
+	if s.Enums != nil {
+		stmt.Enums = make(map[string]EnumField, len(s.Enums))
+		for k, v := range s.Enums {
+			stmt.Enums[k] = v
+		}
+	}
+	stmt.Corrections = append([]Correction(nil), s.Corrections...)
+
+	return &stmt
+}
@@ -0,0 +1,190 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure corrections re-parse field values and are recorded.
+func TestStatement_Apply(t *testing.T) {
+	at := time.Date(2018, time.May, 14, 10, 0, 0, 0, time.UTC)
+
+	var tests = map[string]struct {
+		s    string
+		opts []parser.Option
+		c    parser.Correction
+		exp  func(stmt *parser.Statement)
+		err  string
+	}{
+		"string field": {
+			s: "Yesterday: ibm\nToday: halo",
+			c: parser.Correction{Field: "today", Raw: "  coomo  ", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.Today.Val = "coomo"
+			},
+		},
+
+		"bool field": {
+			s: "LP: updating",
+			c: parser.Correction{Field: "lp", Raw: "up to date", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.LP = parser.BoolField{Key: "LP", Val: true, Lit: "up to date", Valid: true}
+			},
+		},
+
+		"ooo field resolves against the correction time": {
+			s: "OOO: someday",
+			c: parser.Correction{Field: "ooo", Raw: "tomorrow", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.OOO = parser.DateRangeField{
+					Key:   "OOO",
+					From:  date(2018, time.May, 15),
+					To:    date(2018, time.May, 15),
+					Lit:   "tomorrow",
+					Valid: true,
+				}
+			},
+		},
+
+		"declared number": {
+			s:    "Capacity: lots",
+			opts: []parser.Option{parser.WithNumber("capacity")},
+			c:    parser.Correction{Field: "numbers.capacity", Raw: "80%", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.Numbers["capacity"] = parser.NumberField{Key: "Capacity", Val: 80, Unit: "%", Lit: "80%", Valid: true}
+			},
+		},
+
+		"declared enum": {
+			s:    "Mood: purple",
+			opts: []parser.Option{parser.WithEnum("mood", []string{"green", "red"})},
+			c:    parser.Correction{Field: "enums.mood", Raw: "red", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				f := stmt.Enums["mood"]
+				f.Val, f.Lit, f.Valid = "red", "red", true
+				stmt.Enums["mood"] = f
+			},
+		},
+
+		"clearing a field": {
+			s: "Meetings: huddle",
+			c: parser.Correction{Field: "meetings", Raw: "", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.Meetings = parser.StringField{Key: "Meetings"}
+			},
+		},
+
+		"invalid value": {
+			s:   "Jira: done",
+			c:   parser.Correction{Field: "jira", Raw: "maybe", By: "alice", At: at},
+			err: `jira: invalid value "maybe"`,
+		},
+
+		"unknown field": {
+			s:   "Today: halo",
+			c:   parser.Correction{Field: "mood", Raw: "green", By: "alice", At: at},
+			err: `unknown field "mood"`,
+		},
+
+		"yesterday date named by the value": {
+			s:    "Previously: on Wednesday halo",
+			opts: []parser.Option{parser.WithReferenceTime(at)},
+			c:    parser.Correction{Field: "yesterday", Raw: "on Thursday halo", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.Yesterday.Val = "on Thursday halo"
+				stmt.Yesterday.Date = datep(2018, time.May, 10)
+			},
+		},
+
+		"yesterday date no longer named": {
+			s:    "Previously: on Wednesday halo",
+			opts: []parser.Option{parser.WithReferenceTime(at)},
+			c:    parser.Correction{Field: "yesterday", Raw: "halo", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.Yesterday.Val = "halo"
+				stmt.Yesterday.Date = nil
+			},
+		},
+
+		"yesterday date named by the key": {
+			s:    "Friday: ibm",
+			opts: []parser.Option{parser.WithReferenceTime(at)},
+			c:    parser.Correction{Field: "yesterday", Raw: "on Wednesday halo", By: "alice", At: at.AddDate(0, 0, 3)},
+			exp: func(stmt *parser.Statement) {
+				stmt.Yesterday.Val = "on Wednesday halo"
+			},
+		},
+
+		"blockers routes are cleared": {
+			s:    "Blockers: waiting on #design",
+			opts: []parser.Option{parser.WithRoute(parser.Route{Name: "design", Kind: "team", Channel: "#design"})},
+			c:    parser.Correction{Field: "blockers", Raw: "", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.Blockers = parser.StringField{Key: "Blockers"}
+			},
+		},
+
+		"today is linked to goals again": {
+			s: "Goals: ship halo v2\nToday: slack",
+			c: parser.Correction{Field: "today", Raw: "halo deployment", By: "alice", At: at},
+			exp: func(stmt *parser.Statement) {
+				stmt.Today.Val = "halo deployment"
+				stmt.Today.LinkedGoals = []parser.GoalLink{{Item: "halo deployment", Goal: "ship halo v2", Score: 0.5}}
+			},
+		},
+
+		"undeclared check": {
+			s:   "Today: halo",
+			c:   parser.Correction{Field: "checks.timesheet", Raw: "yes", By: "alice", At: at},
+			err: `unknown field "checks.timesheet"`,
+		},
+
+		"bare checks": {
+			s:    "Timesheet: no",
+			opts: []parser.Option{parser.WithCheck("timesheet")},
+			c:    parser.Correction{Field: "checks", Raw: "yes", By: "alice", At: at},
+			err:  `unknown field "checks"`,
+		},
+
+		"undeclared number": {
+			s:   "Today: halo",
+			c:   parser.Correction{Field: "numbers.capacity", Raw: "80%", By: "alice", At: at},
+			err: `unknown field "numbers.capacity"`,
+		},
+
+		"undeclared enum": {
+			s:   "Today: halo",
+			c:   parser.Correction{Field: "enums.mood", Raw: "green", By: "alice", At: at},
+			err: `unknown field "enums.mood"`,
+		},
+	}
+
+	for label, tt := range tests {
+		orig, _ := parser.New(strings.NewReader(tt.s), tt.opts...).Parse()
+		before, _ := parser.New(strings.NewReader(tt.s), tt.opts...).Parse()
+
+		stmt, err := orig.Apply(tt.c)
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("[%v] error mismatch:\n  exp=%s\n  got=%s\n\n", label, tt.err, err)
+			continue
+		} else if tt.err != "" {
+			continue
+		}
+
+		exp, _ := parser.New(strings.NewReader(tt.s), tt.opts...).Parse()
+		tt.exp(exp)
+		exp.Corrections = []parser.Correction{tt.c}
+
+		if !reflect.DeepEqual(exp, stmt) {
+			t.Errorf("[%v] stmt mismatch:\n\nexp=%v\n\ngot=%v\n\n", label, spew.Sdump(exp), spew.Sdump(stmt))
+		}
+		if !reflect.DeepEqual(before, orig) {
+			t.Errorf("[%v] original statement was modified", label)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdays maps lower-case weekday names and abbreviations to their time.Weekday.
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// months maps lower-case month names and abbreviations to their time.Month.
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+// rangeSep splits the two ends of a date range, as in "May 20-24" or "Monday to Wednesday".
+var rangeSep = regexp.MustCompile(`\s*(?:-|–|—|\bto\b|\bthrough\b|\bthru\b|\buntil\b|\btill\b)\s*`)
+
+// monthDay matches "May 20", "May 20th" or a bare day of the month such as "24".
+var monthDay = regexp.MustCompile(`^(?:([a-z]+)\.?\s+)?(\d{1,2})(?:st|nd|rd|th)?$`)
+
+// pastDayPattern matches phrases naming a past working day, such as
+// "yesterday", "last Friday" or "before the long weekend".
+const pastDayPattern = `yesterday|last working day|before the (?:long )?weekend|(?:last|on) (?:monday|tuesday|wednesday|thursday|friday|saturday|sunday)`
+
+// pastDay finds a past day phrase within a value.
+var pastDay = regexp.MustCompile(`(?i)\b(?:` + pastDayPattern + `)\b`)
+
+// pastDayKey matches a section key that is a past day phrase.
+var pastDayKey = regexp.MustCompile(`(?i)^(?:` + pastDayPattern + `)$`)
+
+// resolvePast returns the day the Yesterday section with the given key
+// and value refers to. The key is looked at first and may be a bare
+// weekday ("Friday"); the value must contain a phrase matched by pastDay.
+func (p *Parser) resolvePast(key, val string) (time.Time, bool) {
+	key = strings.ToLower(normalize(key))
+	if i := strings.Index(key, "/"); i > 0 {
+		key = key[:i] // "friday/weekend"
+	}
+
+	phrase := key
+	if _, ok := weekdays[key]; !ok && !pastDayKey.MatchString(key) {
+		if phrase = strings.ToLower(pastDay.FindString(val)); phrase == "" {
+			return time.Time{}, false
+		}
+	}
+
+	day := startOfDay(p.ref)
+	switch {
+	case phrase == "yesterday" || phrase == "last working day":
+		return p.prevWorkingDay(day), true
+
+	case strings.HasPrefix(phrase, "before the"):
+		// Walk back to the weekend, then past it.
+		day = day.AddDate(0, 0, -1)
+		for p.isWorkingDay(day) {
+			day = day.AddDate(0, 0, -1)
+		}
+		return p.prevWorkingDay(day), true
+	}
+
+	wd := weekdays[strings.TrimPrefix(strings.TrimPrefix(phrase, "last "), "on ")]
+	n := (int(day.Weekday()) - int(wd) + 7) % 7
+	if n == 0 {
+		n = 7
+	}
+	return day.AddDate(0, 0, -n), true
+}
+
+// prevWorkingDay returns the last working day strictly before day.
+func (p *Parser) prevWorkingDay(day time.Time) time.Time {
+	day = day.AddDate(0, 0, -1)
+	for !p.isWorkingDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// isWorkingDay is true if day is a weekday and not a holiday.
+func (p *Parser) isWorkingDay(day time.Time) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	return p.calendar == nil || !p.calendar.IsHoliday(day)
+}
+
+// outOfOffice splits a line such as "Out next Thursday" or "PTO May 20-24"
+// into its keyword and the dates it names. It is false unless the line
+// starts with an OOO keyword of one word and the rest is a date or range.
+func outOfOffice(lit string) (key, rest string, ok bool) {
+	lit = strings.TrimLeft(lit, "_*-+> \t")
+	i := strings.IndexAny(lit, " \t")
+	if i < 0 || keywords[normalize(lit[:i])] != OOO {
+		return "", "", false
+	}
+
+	key, rest = lit[:i], strings.TrimSpace(lit[i:])
+	if _, _, err := resolveRange(rest, time.Time{}); err != nil {
+		return "", "", false
+	}
+	return key, rest, true
+}
+
+// resolveRange resolves a date or a range of dates such as "tomorrow",
+// "next Thursday" or "May 20–24" against the reference time ref.
+// It returns the first and last days of the range, inclusive, at midnight.
+func resolveRange(s string, ref time.Time) (from, to time.Time, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.TrimRight(s, ".!")
+	for _, prefix := range []string{"from ", "on ", "all day ", "out "} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+
+	if s == "next week" {
+		from = startOfDay(ref).AddDate(0, 0, 7-int(ref.Weekday())+int(time.Monday))
+		return from, from.AddDate(0, 0, 4), nil
+	}
+
+	parts := rangeSep.Split(s, 2)
+	if from, err = resolveDate(parts[0], ref, time.Month(0)); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if len(parts) == 1 {
+		return from, from, nil
+	}
+	if to, err = resolveDate(parts[1], from, from.Month()); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.New("range ends before it starts")
+	}
+	return from, to, nil
+}
+
+// resolveDate resolves a single day against ref. A bare day of the month
+// is only accepted when month is set, i.e. on the right side of a range.
+func resolveDate(s string, ref time.Time, month time.Month) (time.Time, error) {
+	day := startOfDay(ref)
+
+	switch s {
+	case "today":
+		return day, nil
+	case "tomorrow":
+		return day.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return day.AddDate(0, 0, -1), nil
+	}
+
+	if wd, ok := weekdays[strings.TrimPrefix(s, "next ")]; ok {
+		n := (int(wd) - int(day.Weekday()) + 7) % 7
+		if n == 0 && strings.HasPrefix(s, "next ") {
+			n = 7
+		}
+		return day.AddDate(0, 0, n), nil
+	}
+
+	m := monthDay.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, errors.New("unrecognized date")
+	}
+	if m[1] != "" {
+		var ok bool
+		if month, ok = months[m[1]]; !ok {
+			return time.Time{}, errors.New("unrecognized month")
+		}
+	}
+	if month == 0 {
+		return time.Time{}, errors.New("missing month")
+	}
+
+	d, _ := strconv.Atoi(m[2])
+	t := time.Date(day.Year(), month, d, 0, 0, 0, 0, day.Location())
+	if t.Month() != month {
+		return time.Time{}, errors.New("day out of range")
+	}
+
+	// Dates far in the past most likely refer to next year.
+	if t.Before(day.AddDate(0, -6, 0)) {
+		t = t.AddDate(1, 0, 0)
+	}
+	return t, nil
+}
+
+// startOfDay returns midnight of the day t falls on, in t's location.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
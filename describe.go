@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// builtins describes each built-in section, in the order they are documented.
+var builtins = []struct {
+	field   string
+	title   string
+	value   string
+	example string
+}{
+	{"yesterday", "Yesterday", "text", "ibm, slack"},
+	{"today", "Today", "text", "- halo: finish deployment\n- coomo: architecture planning"},
+	{"goals", "Goals", "text", "ship halo v2"},
+	{"meetings", "Meetings", "text", "huddle, UX review"},
+	{"blockers", "Blockers", "text", "none"},
+	{"ooo", "Out of office", "days, e.g. `tomorrow`, `next Thursday`, `May 20-24`; a line such as `Out next Thursday` needs no colon", "May 20-24"},
+	{"lp", "LP", "yes or no, e.g. `up to date`, `not yet`", "up to date"},
+	{"jira", "Jira", "yes or no, e.g. `up to date`, `not yet`", "up to date"},
+}
+
+// DescribeFormat returns a Markdown description of the format a parser
+// configured with opts accepts: its sections, their keywords and values,
+// and an example. It is built from the same keywords and options the
+// parser uses, so it cannot drift from actual behavior.
+func DescribeFormat(opts ...Option) string {
+	c := Capabilities(opts...)
+	p := New(strings.NewReader(""), opts...)
+
+	var buf bytes.Buffer
+	buf.WriteString("# Standup format\n\n")
+	buf.WriteString("Start each section with one of its keywords, optionally followed by a colon. ")
+	buf.WriteString("Keywords are case-insensitive and may start with a list marker such as `-`.\n\n")
+
+	buf.WriteString("| Section | Keywords | Value |\n")
+	buf.WriteString("|---|---|---|\n")
+	for _, b := range builtins {
+		kws := keywordList(c.Keywords[b.field])
+		if b.field == "yesterday" {
+			kws += ", or a past day such as `last friday`, `on tuesday`, `before the long weekend`"
+		}
+		fmt.Fprintf(&buf, "| %s | %s | %s |\n", b.title, kws, b.value)
+	}
+
+	declared := declaredSections(p)
+	for _, sec := range declared {
+		var kws []string
+		for _, kw := range c.Keywords[sec.field()] {
+			if _, builtin := keywords[kw]; !builtin {
+				kws = append(kws, kw)
+			}
+		}
+		fmt.Fprintf(&buf, "| %s | %s | %s |\n", sec.name, keywordList(kws), sec.describe())
+	}
+
+	buf.WriteString("\n")
+	if title := implicitTitle(p.implicit); title != "" {
+		fmt.Fprintf(&buf, "Text before the first keyword is read as %s.\n", title)
+	} else {
+		buf.WriteString("Every line must belong to a section; text before the first keyword is rejected.\n")
+	}
+	if p.transcript {
+		buf.WriteString("Voice transcripts are accepted: sections start with spoken cues such as \"yesterday I worked on\", \"today I will\" and \"no blockers\".\n")
+	}
+
+	buf.WriteString("\n## Example\n\n```\n")
+	for _, b := range builtins {
+		if b.field == "goals" || b.field == "ooo" {
+			continue
+		}
+		if strings.Contains(b.example, "\n") {
+			fmt.Fprintf(&buf, "%s:\n%s\n", b.title, b.example)
+		} else {
+			fmt.Fprintf(&buf, "%s: %s\n", b.title, b.example)
+		}
+	}
+	for _, sec := range declared {
+		if sec.keyword != "" {
+			fmt.Fprintf(&buf, "%s: %s\n", sec.keyword, sec.example())
+		}
+	}
+	buf.WriteString("```\n")
+
+	return buf.String()
+}
+
+// declaredSection is a declared section along with the first of its
+// keywords that is not shadowed by a built-in one, if any.
+type declaredSection struct {
+	section
+	keyword string
+}
+
+// declaredSections returns the sections declared on p, by kind and name.
+func declaredSections(p *Parser) []declaredSection {
+	byName := map[string]declaredSection{}
+	for kw, sec := range p.sections {
+		key := sec.field()
+		if _, builtin := keywords[kw]; builtin {
+			kw = "" // shadowed by a built-in keyword
+		}
+		if d, ok := byName[key]; !ok || (kw != "" && (d.keyword == "" || kw < d.keyword)) {
+			byName[key] = declaredSection{section: sec, keyword: kw}
+		}
+	}
+
+	// Keywords are compared in their normalized form above, so the choice
+	// does not depend on map order; they are lower-cased for display.
+	secs := make([]declaredSection, 0, len(byName))
+	for _, d := range byName {
+		d.keyword = strings.ToLower(d.keyword)
+		secs = append(secs, d)
+	}
+	sort.Slice(secs, func(i, j int) bool {
+		if secs[i].kind != secs[j].kind {
+			return secs[i].kind < secs[j].kind
+		}
+		return secs[i].name < secs[j].name
+	})
+	return secs
+}
+
+// describe returns the description of the value of the section.
+func (sec section) describe() string {
+	switch sec.kind {
+	case checkSection:
+		return "yes or no"
+	case numberSection:
+		return "number, e.g. `60%`, `4h`, `3`"
+	case enumSection:
+		return "one of " + keywordList(sec.allowed)
+	}
+	return ""
+}
+
+// example returns an example value for the section.
+func (sec section) example() string {
+	switch sec.kind {
+	case checkSection:
+		return "yes"
+	case numberSection:
+		return "3"
+	case enumSection:
+		if len(sec.allowed) > 0 {
+			return sec.allowed[0]
+		}
+	}
+	return ""
+}
+
+// keywordList formats keywords as a Markdown list of code spans.
+func keywordList(kws []string) string {
+	quoted := make([]string, len(kws))
+	for i, kw := range kws {
+		quoted[i] = "`" + strings.ToLower(kw) + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// implicitTitle returns the title of the implicit section, or "" if there is none.
+func implicitTitle(tok Token) string {
+	for _, b := range builtins {
+		if f, ok := fields[tok]; ok && f == b.field {
+			return b.title
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure the format description lists sections and their keywords.
+func TestDescribeFormat(t *testing.T) {
+	opts := []parser.Option{
+		parser.WithCheck("timesheet", "Timesheet submitted", "Timesheet", "LP"),
+		parser.WithNumber("capacity"),
+		parser.WithEnum("mood", []string{"green", "yellow", "red"}),
+		parser.WithImplicitSection(parser.YESTERDAY),
+	}
+	doc := parser.DescribeFormat(opts...)
+
+	for _, s := range []string{
+		"# Standup format\n",
+		"| Today | `today` | text |\n",
+		"| LP | `hours`, `lp`, `time` | yes or no, e.g. `up to date`, `not yet` |\n",
+		"| Out of office | `ooo`, `out`, `out of office`, `pto` |",
+		"| timesheet | `timesheet`, `timesheet submitted` | yes or no |\n",
+		"| capacity | `capacity` | number, e.g. `60%`, `4h`, `3` |\n",
+		"| mood | `mood` | one of `green`, `yellow`, `red` |\n",
+		"Text before the first keyword is read as Yesterday.\n",
+	} {
+		if !strings.Contains(doc, s) {
+			t.Errorf("expected description to contain %q, got:\n%s", s, doc)
+		}
+	}
+}
+
+// Ensure the example in the description parses into valid sections.
+func TestDescribeFormat_Example(t *testing.T) {
+	opts := []parser.Option{
+		parser.WithCheck("timesheet", "Timesheet submitted", "Timesheet"),
+		parser.WithNumber("capacity"),
+		parser.WithEnum("mood", []string{"green", "yellow", "red"}),
+	}
+	doc := parser.DescribeFormat(opts...)
+
+	start := strings.Index(doc, "```\n") + len("```\n")
+	end := strings.LastIndex(doc, "```")
+	stmt, err := parser.New(strings.NewReader(doc[start:end]), opts...).Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, valid := range map[string]bool{
+		"yesterday": stmt.Yesterday.Valid,
+		"today":     stmt.Today.Valid,
+		"meetings":  stmt.Meetings.Valid,
+		"blockers":  stmt.Blockers.Valid,
+		"lp":        stmt.LP.Valid,
+		"jira":      stmt.Jira.Valid,
+		"timesheet": stmt.Checks["timesheet"].Valid,
+		"capacity":  stmt.Numbers["capacity"].Valid,
+		"mood":      stmt.Enums["mood"].Valid,
+	} {
+		if !valid {
+			t.Errorf("expected example %s to be valid:\n%s", name, doc[start:end])
+		}
+	}
+}
+
+// Ensure the description does not depend on map order.
+func TestDescribeFormat_Deterministic(t *testing.T) {
+	opt := parser.WithCheck("timesheet", "Timesheet submitted", "Timesheet", "TS")
+	doc := parser.DescribeFormat(opt)
+	if !strings.Contains(doc, "\ntimesheet: yes\n") {
+		t.Errorf("expected the first keyword in the example, got:\n%s", doc)
+	}
+
+	for i := 0; i < 100; i++ {
+		if got := parser.DescribeFormat(opt); got != doc {
+			t.Fatalf("description changed between calls:\n\nexp=\n%s\n\ngot=\n%s", doc, got)
+		}
+	}
+}
+
+// Ensure a format without an implicit section says so.
+func TestDescribeFormat_WithoutImplicitSection(t *testing.T) {
+	doc := parser.DescribeFormat(parser.WithoutImplicitSection())
+	if !strings.Contains(doc, "text before the first keyword is rejected") {
+		t.Errorf("expected description to mention rejected text, got:\n%s", doc)
+	}
+}
@@ -0,0 +1,209 @@
+// Package flow collects a standup one question at a time, for people who
+// prefer to be interviewed by the bot over writing a free-form post.
+//
+// Each answer is parsed and validated as the value of its section before
+// the flow moves on. Unlike in a post, keywords in an answer do not start
+// another section, and Today is linked to the goals given with
+// parser.WithGoals, not to those of a later Goals answer.
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// ErrDone is returned when answering a flow that has no questions left.
+var ErrDone = errors.New("flow: no questions left")
+
+// Question asks for one section of a standup.
+type Question struct {
+	// Field is the field the answer goes to, named as in parser.Correction,
+	// e.g. "today" or "checks.timesheet".
+	Field string
+
+	// Key is the keyword the answer is parsed under, e.g. "Today".
+	// Declared sections must use one of their aliases.
+	Key string
+
+	Prompt   string
+	Optional bool
+}
+
+// DefaultQuestions asks for the built-in sections.
+var DefaultQuestions = []Question{
+	{Field: "yesterday", Key: "Yesterday", Prompt: "What did you work on yesterday?"},
+	{Field: "today", Key: "Today", Prompt: "What are you working on today?"},
+	{Field: "meetings", Key: "Meetings", Prompt: "Any meetings today?", Optional: true},
+	{Field: "blockers", Key: "Blockers", Prompt: "Is anything blocking you?", Optional: true},
+	{Field: "lp", Key: "LP", Prompt: "Is LP up to date?"},
+	{Field: "jira", Key: "Jira", Prompt: "Is Jira up to date?"},
+}
+
+// Flow asks a list of questions in order and assembles a Statement
+// from the answers.
+type Flow struct {
+	questions []Question
+	opts      []parser.Option
+	stmt      *parser.Statement
+	i         int // index of the current question
+}
+
+// New returns a new instance of Flow asking questions in order.
+// Answers are parsed with opts.
+func New(questions []Question, opts ...parser.Option) *Flow {
+	return &Flow{questions: questions, opts: opts, stmt: &parser.Statement{Version: parser.Version()}}
+}
+
+// Question returns the current question, or false if the flow is done.
+func (f *Flow) Question() (Question, bool) {
+	if f.Done() {
+		return Question{}, false
+	}
+	return f.questions[f.i], true
+}
+
+// Answer parses text as the answer to the current question. If the
+// answer is valid it is added to the statement and the flow moves to the
+// next question; otherwise the error tells the person what is expected
+// and the question stays current.
+func (f *Flow) Answer(text string) error {
+	q, ok := f.Question()
+	if !ok {
+		return ErrDone
+	}
+
+	if strings.TrimSpace(text) == "" {
+		if q.Optional {
+			return f.Skip()
+		}
+		return errors.New("please answer the question")
+	}
+
+	src, err := parser.New(strings.NewReader(text), f.opts...).ParseSection(q.Key)
+	if err != nil {
+		return err
+	}
+	if err := copyField(f.stmt, src, q.Field); err != nil {
+		return err
+	}
+
+	f.i++
+	return nil
+}
+
+// Skip moves past the current question if it is optional.
+func (f *Flow) Skip() error {
+	q, ok := f.Question()
+	if !ok {
+		return ErrDone
+	}
+	if !q.Optional {
+		return errors.New("this question cannot be skipped")
+	}
+
+	f.i++
+	return nil
+}
+
+// Done is true if every question was answered or skipped.
+func (f *Flow) Done() bool { return f.i >= len(f.questions) }
+
+// Statement returns the statement assembled so far.
+func (f *Flow) Statement() *parser.Statement { return f.stmt }
+
+// copyField copies field from src to dst if src holds a valid value for it.
+func copyField(dst, src *parser.Statement, field string) error {
+	name, sub := field, ""
+	if i := strings.Index(field, "."); i >= 0 {
+		name, sub = field[:i], field[i+1:]
+	}
+
+	str := func(d *parser.StringField, s parser.StringField) error {
+		if !s.Valid {
+			return errors.New("please answer the question")
+		}
+		*d = s
+		return nil
+	}
+	check := func(s parser.BoolField) error {
+		if !s.Valid {
+			return errors.New("please answer yes or no")
+		}
+		return nil
+	}
+
+	switch name {
+	case "yesterday":
+		return str(&dst.Yesterday, src.Yesterday)
+	case "today":
+		return str(&dst.Today, src.Today)
+	case "meetings":
+		return str(&dst.Meetings, src.Meetings)
+	case "blockers":
+		return str(&dst.Blockers, src.Blockers)
+	case "goals":
+		return str(&dst.Goals, src.Goals)
+	case "ooo":
+		if !src.OOO.Valid {
+			return errors.New("please answer with a date, such as tomorrow or May 20-24")
+		}
+		dst.OOO = src.OOO
+		return nil
+	case "lp":
+		if err := check(src.LP); err != nil {
+			return err
+		}
+		dst.LP = src.LP
+		return nil
+	case "jira":
+		if err := check(src.Jira); err != nil {
+			return err
+		}
+		dst.Jira = src.Jira
+		return nil
+	case "checks":
+		s, ok := src.Checks[sub]
+		if !ok {
+			break
+		}
+		if err := check(s); err != nil {
+			return err
+		}
+		if dst.Checks == nil {
+			dst.Checks = map[string]parser.BoolField{}
+		}
+		dst.Checks[sub] = s
+		return nil
+	case "numbers":
+		s, ok := src.Numbers[sub]
+		if !ok {
+			break
+		}
+		if !s.Valid {
+			return errors.New("please answer with a number")
+		}
+		if dst.Numbers == nil {
+			dst.Numbers = map[string]parser.NumberField{}
+		}
+		dst.Numbers[sub] = s
+		return nil
+	case "enums":
+		s, ok := src.Enums[sub]
+		if !ok {
+			break
+		}
+		if err := s.Err(); err != nil {
+			return err
+		}
+		if dst.Enums == nil {
+			dst.Enums = map[string]parser.EnumField{}
+		}
+		dst.Enums[sub] = s
+		return nil
+	}
+
+	return fmt.Errorf("flow: unknown field %q", field)
+}
@@ -0,0 +1,98 @@
+package flow_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/olivoil/standup-parser"
+	"github.com/olivoil/standup-parser/flow"
+)
+
+// Ensure a flow asks each question in order and assembles a statement.
+func TestFlow_Answer(t *testing.T) {
+	f := flow.New(append(flow.DefaultQuestions, flow.Question{
+		Field:  "numbers.capacity",
+		Key:    "Capacity",
+		Prompt: "How much capacity do you have?",
+	}), parser.WithNumber("capacity"))
+
+	var steps = []struct {
+		field  string
+		answer string
+		skip   bool
+		err    string
+	}{
+		{field: "yesterday", answer: "PTO"},
+		{field: "today", answer: "", err: "please answer the question"},
+		{field: "today", answer: "- halo\n- meeting\n- time: fix bugs"},
+		{field: "meetings", skip: true},
+		{field: "blockers", answer: "  "},
+		{field: "lp", answer: "maybe", err: "please answer yes or no"},
+		{field: "lp", answer: "up to date"},
+		{field: "jira", answer: "not yet"},
+		{field: "numbers.capacity", answer: "lots", err: "please answer with a number"},
+		{field: "numbers.capacity", answer: "60%"},
+	}
+
+	for i, st := range steps {
+		q, ok := f.Question()
+		if !ok {
+			t.Fatalf("%d. flow ended early", i)
+		} else if q.Field != st.field {
+			t.Fatalf("%d. question mismatch: exp=%s got=%s", i, st.field, q.Field)
+		}
+
+		var err error
+		if st.skip {
+			err = f.Skip()
+		} else {
+			err = f.Answer(st.answer)
+		}
+
+		if errstring(err) != st.err {
+			t.Errorf("%d. error mismatch: exp=%q got=%q", i, st.err, err)
+		}
+	}
+
+	if !f.Done() {
+		t.Fatalf("expected flow to be done")
+	}
+	if err := f.Answer("more"); err != flow.ErrDone {
+		t.Errorf("expected ErrDone, got %v", err)
+	}
+
+	exp := &parser.Statement{
+		Version:   parser.Version(),
+		Yesterday: parser.StringField{Key: "Yesterday", Val: "PTO", Valid: true},
+		Today:     parser.StringField{Key: "Today", Val: "- halo\n- meeting\n- time: fix bugs", Valid: true},
+		LP:        parser.BoolField{Key: "LP", Val: true, Lit: "up to date", Valid: true},
+		Jira:      parser.BoolField{Key: "Jira", Val: false, Lit: "not yet", Valid: true},
+		Numbers: map[string]parser.NumberField{
+			"capacity": {Key: "Capacity", Val: 60, Unit: "%", Lit: "60%", Valid: true},
+		},
+	}
+	if got := f.Statement(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("statement mismatch:\n\nexp=%#v\n\ngot=%#v", exp, got)
+	}
+}
+
+// Ensure required questions cannot be skipped and unknown fields are reported.
+func TestFlow_Errors(t *testing.T) {
+	f := flow.New([]flow.Question{{Field: "today", Key: "Today"}})
+	if err := f.Skip(); errstring(err) != "this question cannot be skipped" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	f = flow.New([]flow.Question{{Field: "checks.timesheet", Key: "Timesheet"}})
+	if err := f.Answer("yes"); errstring(err) != `"Timesheet" is not a section keyword` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// errstring returns the string representation of an error.
+func errstring(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
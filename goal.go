@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// GoalThreshold is the score an item needs to be linked to a goal.
+const GoalThreshold = 0.5
+
+// GoalLink links an item of the Today section to the goal it works towards.
+type GoalLink struct {
+	Item  string  `json:"item"`
+	Goal  string  `json:"goal"`
+	Score float64 `json:"score"` // between GoalThreshold and 1
+}
+
+// itemMarker matches a list marker at the start of an item.
+var itemMarker = regexp.MustCompile(`^(?:[-*+>•]|\d+[.)](?:\s|$))\s*`)
+
+// Items splits the value of a section into its items: one per line, or per
+// comma or semicolon within a line, without list markers or blank items.
+func Items(s string) []string {
+	var items []string
+	for _, line := range strings.Split(s, "\n") {
+		line = itemMarker.ReplaceAllString(strings.TrimSpace(line), "")
+		for _, item := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ';' }) {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// LinkGoals links each item to the goal it shares the most words with,
+// if any. Words match when they are equal or only differ by their ending,
+// as "deploy" and "deployment" do. The score is the share of the words of
+// the shorter of the item and the goal that match.
+func LinkGoals(goals, items []string) []GoalLink {
+	goalWords := make([][]string, len(goals))
+	for i, g := range goals {
+		goalWords[i] = goalTerms(g)
+	}
+
+	var links []GoalLink
+	for _, item := range items {
+		words := goalTerms(item)
+
+		best, bestScore, bestDice := -1, 0.0, 0.0
+		for i := range goals {
+			score, dice := overlap(words, goalWords[i])
+			if score > bestScore || (score == bestScore && dice > bestDice) {
+				best, bestScore, bestDice = i, score, dice
+			}
+		}
+		if best >= 0 && bestScore >= GoalThreshold {
+			links = append(links, GoalLink{Item: item, Goal: goals[best], Score: bestScore})
+		}
+	}
+	return links
+}
+
+// linkGoals links the items of the Today section of stmt to its goals, or
+// to the goals carried over with WithGoals if it has no Goals section.
+func (p *Parser) linkGoals(stmt *Statement) {
+	goals := p.goals
+	if stmt.Goals.Valid {
+		goals = Items(stmt.Goals.Val)
+	}
+	stmt.Today.LinkedGoals = LinkGoals(goals, Items(stmt.Today.Val))
+}
+
+// stopWords are left out when matching items to goals.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "of": true, "for": true,
+	"to": true, "on": true, "in": true, "with": true, "at": true, "by": true,
+	"is": true, "be": true, "it": true, "my": true, "our": true, "some": true,
+}
+
+// goalTerms returns the distinct lower-case words of s, without stop words.
+func goalTerms(s string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !stopWords[w] && !seen[w] {
+			seen[w] = true
+			terms = append(terms, w)
+		}
+	}
+	return terms
+}
+
+// overlap returns the share of the words of the shorter of a and b that
+// match a word of the other, and the Dice coefficient of the matches.
+func overlap(a, b []string) (score, dice float64) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, 0
+	}
+
+	var n int
+	for _, x := range a {
+		for _, y := range b {
+			if sameWord(x, y) {
+				n++
+				break
+			}
+		}
+	}
+
+	min := len(a)
+	if len(b) < min {
+		min = len(b)
+	}
+	if n > min {
+		n = min
+	}
+	return float64(n) / float64(min), 2 * float64(n) / float64(len(a)+len(b))
+}
+
+// sameWord is true if a and b are equal, or share a prefix of at least
+// four letters and differ by at most two more letters of the shorter one,
+// as "deployed" and "deploying" do but "release" and "relevant" do not.
+func sameWord(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(rb) < len(ra) {
+		ra, rb = rb, ra
+	}
+	n := 0
+	for n < len(ra) && ra[n] == rb[n] {
+		n++
+	}
+	return n >= 4 && n >= len(ra)-2
+}
@@ -0,0 +1,67 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure section values are split into items.
+func TestItems(t *testing.T) {
+	got := parser.Items("- halo: deploy, QA\n\n2. coomo; ibm\n* 3.5 hours on knod")
+	exp := []string{"halo: deploy", "QA", "coomo", "ibm", "3.5 hours on knod"}
+	if !reflect.DeepEqual(exp, got) {
+		t.Errorf("items mismatch:\n  exp=%q\n  got=%q", exp, got)
+	}
+}
+
+// Ensure items are linked to the goal they share the most words with.
+func TestLinkGoals(t *testing.T) {
+	goals := []string{"ship halo v2", "halo docs", "billing migration"}
+	items := []string{"halo v2 deployment", "migrate the billing tables", "halo", "relevant slack threads"}
+
+	exp := []parser.GoalLink{
+		{Item: "halo v2 deployment", Goal: "ship halo v2", Score: 2.0 / 3},
+		{Item: "migrate the billing tables", Goal: "billing migration", Score: 1},
+		{Item: "halo", Goal: "halo docs", Score: 1},
+	}
+	if got := parser.LinkGoals(goals, items); !reflect.DeepEqual(exp, got) {
+		t.Errorf("links mismatch:\n  exp=%+v\n  got=%+v", exp, got)
+	}
+}
+
+// Ensure Today is linked to the statement's goals, or to carried over ones.
+func TestParser_Goals(t *testing.T) {
+	var tests = map[string]struct {
+		s    string
+		opts []parser.Option
+		exp  []parser.GoalLink
+	}{
+		"goals of the statement": {
+			s:   "Goals: ship halo v2\nToday: halo deployment, slack",
+			exp: []parser.GoalLink{{Item: "halo deployment", Goal: "ship halo v2", Score: 0.5}},
+		},
+
+		"carried over goals": {
+			s:    "Today: migrate billing",
+			opts: []parser.Option{parser.WithGoals("billing migration")},
+			exp:  []parser.GoalLink{{Item: "migrate billing", Goal: "billing migration", Score: 1}},
+		},
+
+		"goals of the statement replace carried over ones": {
+			s:    "This week: knod QA\nToday: migrate billing",
+			opts: []parser.Option{parser.WithGoals("billing migration")},
+		},
+	}
+
+	for label, tt := range tests {
+		stmt, err := parser.New(strings.NewReader(tt.s), tt.opts...).Parse()
+		if err != nil {
+			t.Errorf("[%v] unexpected error: %s", label, err)
+		} else if !reflect.DeepEqual(tt.exp, stmt.Today.LinkedGoals) {
+			t.Errorf("[%v] links mismatch:\n  exp=%+v\n  got=%+v", label, tt.exp, stmt.Today.LinkedGoals)
+		}
+	}
+}
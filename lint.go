@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Lint returns the problems with a parser configuration, such as declared
+// keywords that can never match because a built-in keyword or another
+// section takes precedence. An empty result means no problems were found.
+func Lint(opts ...Option) []string {
+	p := New(strings.NewReader(""), opts...)
+	problems := append([]string(nil), p.conflicts...)
+
+	kws := make([]string, 0, len(p.sections))
+	for kw := range p.sections {
+		kws = append(kws, kw)
+	}
+	sort.Strings(kws)
+
+	for _, kw := range kws {
+		sec := p.sections[kw]
+		if tok, ok := keywords[kw]; ok {
+			problems = append(problems, fmt.Sprintf("keyword %q of %s is unreachable: it is a built-in keyword for %s",
+				strings.ToLower(kw), sec.field(), fields[tok]))
+		} else if pastDayKey.MatchString(kw) {
+			problems = append(problems, fmt.Sprintf("keyword %q of %s is unreachable: it names a past day, which starts yesterday",
+				strings.ToLower(kw), sec.field()))
+		}
+		if sec.kind == enumSection && len(sec.allowed) == 0 {
+			problems = append(problems, fmt.Sprintf("%s has no allowed values", sec.field()))
+		}
+	}
+
+	if _, ok := fields[p.implicit]; !ok && p.implicit != EOF {
+		problems = append(problems, fmt.Sprintf("implicit section %s is not a built-in section; text before the first keyword will be rejected", p.implicit))
+	}
+
+	return problems
+}
@@ -0,0 +1,62 @@
+package parser_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure configuration problems are reported.
+func TestLint(t *testing.T) {
+	var tests = map[string]struct {
+		opts []parser.Option
+		exp  []string
+	}{
+		"no options": {},
+
+		"valid declarations": {
+			opts: []parser.Option{
+				parser.WithCheck("timesheet", "Timesheet"),
+				parser.WithNumber("capacity"),
+				parser.WithImplicitSection(parser.YESTERDAY),
+			},
+		},
+
+		"shadowed aliases": {
+			opts: []parser.Option{
+				parser.WithCheck("timesheet", "Timesheet", "- Hours"),
+				parser.WithCheck("friday", "Last Friday"),
+			},
+			exp: []string{
+				`keyword "hours" of checks.timesheet is unreachable: it is a built-in keyword for lp`,
+				`keyword "last friday" of checks.friday is unreachable: it names a past day, which starts yesterday`,
+			},
+		},
+
+		"conflicting declarations": {
+			opts: []parser.Option{
+				parser.WithCheck("reviews", "Reviews"),
+				parser.WithNumber("reviews", "Reviews"),
+				parser.WithEnum("mood", nil),
+			},
+			exp: []string{
+				`keyword "reviews" is declared for both checks.reviews and numbers.reviews; numbers.reviews wins`,
+				`enums.mood has no allowed values`,
+			},
+		},
+
+		"invalid implicit section": {
+			opts: []parser.Option{parser.WithImplicitSection(parser.SECTION)},
+			exp: []string{
+				`implicit section SECTION is not a built-in section; text before the first keyword will be rejected`,
+			},
+		},
+	}
+
+	for label, tt := range tests {
+		if got := parser.Lint(tt.opts...); !reflect.DeepEqual(tt.exp, got) {
+			t.Errorf("[%v] problems mismatch:\n  exp=%q\n  got=%q", label, tt.exp, got)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Option configures a Parser.
+type Option func(*Parser)
+
+// WithReferenceTime sets the time the statement was posted at. Relative
+// dates such as "tomorrow" or "next Thursday" are resolved against it.
+// Without a reference time, dates are left unresolved.
+func WithReferenceTime(t time.Time) Option {
+	return func(p *Parser) { p.ref = t }
+}
+
+// WithLocation sets the time zone of the person posting the statement.
+// The reference time is converted to it, so "today" and "yesterday"
+// follow their day boundaries and resolved dates are midnight in loc.
+func WithLocation(loc *time.Location) Option {
+	return func(p *Parser) { p.loc = loc }
+}
+
+// WithCalendar sets the holidays a team observes. Holidays are skipped,
+// like weekends, when resolving "yesterday" or "before the long weekend".
+func WithCalendar(c Calendar) Option {
+	return func(p *Parser) { p.calendar = c }
+}
+
+// WithImplicitSection sets the section that text before the first
+// keyword belongs to, TODAY by default. Teams posting in the morning may
+// prefer YESTERDAY. tok must be one of the built-in section tokens.
+func WithImplicitSection(tok Token) Option {
+	return func(p *Parser) { p.implicit = tok }
+}
+
+// WithoutImplicitSection makes text before the first keyword an error
+// instead of assigning it to a section.
+func WithoutImplicitSection() Option {
+	return func(p *Parser) { p.implicit = EOF }
+}
+
+// WithTranscript parses the input as a speech-to-text transcript, where
+// there are no colons and sections start with spoken cues such as
+// "yesterday I worked on", "today I will" or "no blockers". The whole
+// input is read before parsing starts.
+func WithTranscript() Option {
+	return func(p *Parser) { p.transcript = true }
+}
+
+// WithMaxSize makes Parse fail with ErrOversized when the input is
+// larger than n bytes. By default the input size is not limited.
+func WithMaxSize(n int) Option {
+	return func(p *Parser) { p.maxSize = n }
+}
+
+// WithRepair fixes the encoding of the input before scanning: mojibake
+// from text decoded with the wrong encoding is restored, invalid UTF-8 is
+// read as Windows-1252 or replaced, and stray control characters are
+// dropped. Parser.Repairs reports what was fixed. The whole input is read
+// before parsing starts.
+func WithRepair() Option {
+	return func(p *Parser) { p.repair = true }
+}
+
+// WithRoute adds r to the directory blockers are routed against. When
+// the Blockers section mentions one of the aliases, or the route's name
+// if none is given, r is added to Statement.Blockers.RoutedTo. Aliases
+// match whole words, ignoring case and a leading "@" or "#".
+func WithRoute(r Route, aliases ...string) Option {
+	if len(aliases) == 0 {
+		aliases = []string{r.Name}
+	}
+
+	// Compile the aliases once, not for every statement parsed.
+	directory := make([]routeAlias, len(aliases))
+	for i, alias := range aliases {
+		directory[i] = newRouteAlias(alias, r)
+	}
+	return func(p *Parser) { p.directory = append(p.directory, directory...) }
+}
+
+// WithGoals sets the goals carried over from earlier statements, such as
+// the items of the Goals section posted at the start of the week. Items of
+// the Today section are linked to them in Today.LinkedGoals, unless the
+// statement has a Goals section of its own, which replaces them.
+func WithGoals(goals ...string) Option {
+	return func(p *Parser) { p.goals = goals }
+}
+
+// WithCheck declares an additional boolean check-in section, such as
+// "Timesheet submitted" or "PR reviews done". Its value is classified
+// like LP and Jira and stored in Statement.Checks under name.
+//
+// The section is recognized by any of its aliases, or by its name if no
+// alias is given. Built-in keywords take precedence over aliases.
+func WithCheck(name string, aliases ...string) Option {
+	return withSection(section{name: name, kind: checkSection}, aliases)
+}
+
+// WithNumber declares an additional numeric section, such as "Capacity"
+// or "Focus hours". Values like "60%", "4h" or "3" are parsed into a
+// NumberField and stored in Statement.Numbers under name.
+//
+// Aliases behave as for WithCheck.
+func WithNumber(name string, aliases ...string) Option {
+	return withSection(section{name: name, kind: numberSection}, aliases)
+}
+
+// WithEnum declares an additional section whose value must be one of
+// allowed, such as "Mood: green/yellow/red" or "Location: office/remote".
+// Values are matched case-insensitively and stored as an EnumField in
+// Statement.Enums under name; values outside allowed are marked invalid.
+//
+// Aliases behave as for WithCheck.
+func WithEnum(name string, allowed []string, aliases ...string) Option {
+	return withSection(section{name: name, kind: enumSection, allowed: allowed}, aliases)
+}
+
+// withSection registers sec under each of its aliases.
+func withSection(sec section, aliases []string) Option {
+	// Options may be applied from several goroutines, as ParseArchive
+	// does, so the closure must not write to aliases.
+	if len(aliases) == 0 {
+		aliases = []string{sec.name}
+	}
+	return func(p *Parser) {
+		for _, alias := range aliases {
+			kw := normalize(alias)
+			if prev, ok := p.sections[kw]; ok && (prev.name != sec.name || prev.kind != sec.kind) {
+				p.conflicts = append(p.conflicts, fmt.Sprintf("keyword %q is declared for both %s and %s; %s wins",
+					strings.ToLower(kw), prev.field(), sec.field(), sec.field()))
+			}
+			p.sections[kw] = sec
+		}
+	}
+}
+
+// section is a section declared with an Option.
+type section struct {
+	name    string
+	kind    sectionKind
+	allowed []string // for enumSection
+}
+
+// field returns the name of the section as used in Correction.Field.
+func (sec section) field() string { return sectionFields[sec.kind] + "." + sec.name }
+
+// sectionKind determines how the value of a declared section is parsed.
+type sectionKind int
+
+const (
+	checkSection  sectionKind = iota // BoolField in Statement.Checks
+	numberSection                    // NumberField in Statement.Numbers
+	enumSection                      // EnumField in Statement.Enums
+)
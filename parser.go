@@ -1,20 +1,45 @@
 package parser
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 )
 
 // Statement represents a standup statement.
 type Statement struct {
+	// Version is the version of the parser that produced the statement.
+	// It is set by Parse and kept when the statement is stored, so
+	// statements produced by older versions can be found and re-parsed.
+	Version string `json:"version"`
+
 	Yesterday StringField `json:"yesterday"`
 	Today     StringField `json:"today"`
 	Meetings  StringField `json:"meetings"`
 	Blockers  StringField `json:"blockers"`
+	Goals     StringField `json:"goals"`
+	OOO       DateRangeField `json:"ooo"`
 	LP        BoolField `json:"lp"`
 	Jira      BoolField `json:"jira"`
+
+	// Checks holds the boolean sections declared with WithCheck, by name.
+	Checks map[string]BoolField `json:"checks,omitempty"`
+
+	// Numbers holds the numeric sections declared with WithNumber, by name.
+	Numbers map[string]NumberField `json:"numbers,omitempty"`
+
+	// Enums holds the enum sections declared with WithEnum, by name.
+	Enums map[string]EnumField `json:"enums,omitempty"`
+
+	// Corrections lists the corrections applied with Apply, in order.
+	Corrections []Correction `json:"corrections,omitempty"`
 }
 
 // StringField is a key/value pair that holds one or several string values
@@ -22,6 +47,18 @@ type StringField struct {
 	Key   string `json:"key"`
 	Val   string `json:"val"`
 	Valid bool `json:"valid"`
+
+	// Date is the day the field refers to, when it can be resolved.
+	// It is only set on Yesterday, and requires WithReferenceTime.
+	Date *time.Time `json:"date,omitempty"`
+
+	// RoutedTo lists who the field mentions among the routes added with
+	// WithRoute. It is only set on Blockers.
+	RoutedTo []Route `json:"routedTo,omitempty"`
+
+	// LinkedGoals links the items of the field to the goals of the
+	// statement, or those carried over with WithGoals. It is only set on Today.
+	LinkedGoals []GoalLink `json:"linkedGoals,omitempty"`
 }
 
 // BoolField is a key/value pair that holds one boolean value
@@ -32,6 +69,42 @@ type BoolField struct {
 	Valid bool `json:"valid"`
 }
 
+// NumberField is a key/value pair that holds one numeric value
+type NumberField struct {
+	Key   string  `json:"key"`
+	Val   float64 `json:"val"`
+	Unit  string  `json:"unit"` // "%", "h" or empty
+	Lit   string  `json:"lit"`
+	Valid bool    `json:"valid"`
+}
+
+// EnumField is a key/value pair that holds one of a set of allowed values
+type EnumField struct {
+	Key     string   `json:"key"`
+	Val     string   `json:"val"`
+	Lit     string   `json:"lit"`
+	Allowed []string `json:"allowed"`
+	Valid   bool     `json:"valid"`
+}
+
+// Err returns an error describing why the field is invalid, or nil.
+func (f EnumField) Err() error {
+	if f.Valid {
+		return nil
+	}
+	return fmt.Errorf("%q is not one of %s", f.Lit, strings.Join(f.Allowed, ", "))
+}
+
+// DateRangeField is a key/value pair that holds a range of days.
+// From and To are the first and last days, inclusive, at midnight.
+type DateRangeField struct {
+	Key   string    `json:"key"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Lit   string    `json:"lit"`
+	Valid bool      `json:"valid"`
+}
+
 // Parser represents a parser.
 type Parser struct {
 	s   *Scanner
@@ -40,16 +113,62 @@ type Parser struct {
 		lit string // last read literal
 		n   int    // buffer size (max=1)
 	}
+
+	sections  map[string]section // declared sections, by normalized keyword
+	ref       time.Time          // reference time for resolving dates
+	calendar  Calendar           // holidays skipped when resolving dates
+	loc       *time.Location     // location the day boundaries are taken in
+	directory []routeAlias       // routes blockers are resolved against
+	goals     []string           // goals carried over from earlier statements
+	conflicts []string           // keywords declared for several sections
+
+	implicit   Token        // section of text before the first keyword
+	transcript bool         // input is a speech-to-text transcript
+	maxSize    int          // maximum input size, 0 for no limit
+	limit      *limitReader // enforces maxSize
+	repair     bool         // fix the encoding of the input before scanning
+	repairs    []Repair     // what was fixed
+	err        error        // error reading the input, returned by Parse
 }
 
 // New returns a new instance of Parser.
-func New(r io.Reader) *Parser {
-	return &Parser{s: NewScanner(r)}
+func New(r io.Reader, opts ...Option) *Parser {
+	p := &Parser{sections: map[string]section{}, implicit: TODAY}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.loc != nil && !p.ref.IsZero() {
+		p.ref = p.ref.In(p.loc)
+	}
+	if p.maxSize > 0 {
+		p.limit = &limitReader{r: r, n: p.maxSize}
+		r = p.limit
+	}
+	if p.repair {
+		var b []byte
+		if b, p.err = ioutil.ReadAll(r); p.err == nil {
+			b, p.repairs = repair(b)
+			r = bytes.NewReader(b)
+		}
+	}
+	if p.transcript && p.err == nil {
+		r, p.err = rewriteTranscript(r)
+	}
+	p.s = NewScanner(r)
+	p.s.sections = p.sections
+	return p
 }
 
+// Repairs returns what WithRepair fixed in the input.
+func (p *Parser) Repairs() []Repair { return p.repairs }
+
 // Parse parses a Statement.
 func (p *Parser) Parse() (*Statement, error) {
-	stmt := &Statement{}
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	stmt := &Statement{Version: version}
 
 	// loop over all tokens
 	for {
@@ -59,21 +178,31 @@ func (p *Parser) Parse() (*Statement, error) {
 			break
 		}
 
-		// if it does not start with a keyword, consider it's TODAY
-		if !isKeyword(key) {
+		values := []string{}
+
+		// a line such as "Out next Thursday" is an OOO section without
+		// a colon. Otherwise, if it does not start with a keyword, consider
+		// it's the implicit section (TODAY unless configured otherwise)
+		if oooKey, rest, ok := outOfOffice(keyLit); key == IDENT && ok {
+			key, keyLit = OOO, oooKey
+			values = append(values, rest)
+		} else if !isKeyword(key) {
+			if _, ok := fields[p.implicit]; !ok {
+				return nil, fmt.Errorf("found %q, expected a section keyword", strings.TrimSpace(keyLit))
+			}
 			p.unscan()
-			key = TODAY
+			key = p.implicit
 			keyLit = ""
 		}
 
 		// keyword is optionally followed by a colon. Ignore it.
-		col, _, _ := p.scanIgnoreWhitespace()
-		if col != COLON {
-			p.unscan()
+		if len(values) == 0 {
+			col, _, _ := p.scanIgnoreWhitespace()
+			if col != COLON {
+				p.unscan()
+			}
 		}
 
-		values := []string{}
-
 		for {
 			tok, lit, ws := p.scanIgnoreWhitespace()
 			if isKeyword(tok) || tok == EOF {
@@ -81,74 +210,185 @@ func (p *Parser) Parse() (*Statement, error) {
 				break
 			}
 
+			// an OOO line starts a new section
+			if _, _, ok := outOfOffice(lit); tok == IDENT && strings.Contains(ws, "\n") && ok {
+				p.unscan()
+				break
+			}
+
 			if tok == IDENT || tok == COLON {
 				values = append(values, ws, lit)
 			}
 		}
 
-		switch key {
-		case TODAY:
-			val := splitAndTrimSpace(values)
-			stmt.Today = StringField{
-				Key:   keyLit,
-				Val:   val,
-				Valid: val != "",
-			}
-		case YESTERDAY:
-			val := splitAndTrimSpace(values)
-			stmt.Yesterday = StringField{
-				Key:   keyLit,
-				Val:   val,
-				Valid: val != "",
-			}
-		case MEETINGS:
-			val := splitAndTrimSpace(values)
-			stmt.Meetings = StringField{
-				Key:   keyLit,
-				Val:   val,
-				Valid: val != "",
-			}
-		case BLOCKERS:
-			val := splitAndTrimSpace(values)
-			stmt.Blockers = StringField{
-				Key:   keyLit,
-				Val:   val,
-				Valid: val != "",
+		p.assign(stmt, key, keyLit, splitAndTrimSpace(values))
+	}
+
+	if p.limit != nil && p.limit.exceeded {
+		return nil, ErrOversized
+	}
+
+	p.linkGoals(stmt)
+	return stmt, nil
+}
+
+// ParseSection parses the whole input as the value of the section
+// introduced by key, such as "Today" or the alias of a declared section.
+// Keywords are not looked for in the input, so every line of it belongs
+// to that one section.
+func (p *Parser) ParseSection(key string) (*Statement, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	s := NewScanner(strings.NewReader(key))
+	s.sections = p.sections
+	tok, keyLit := s.Scan()
+	if !isKeyword(tok) {
+		return nil, fmt.Errorf("%q is not a section keyword", key)
+	}
+
+	var values []string
+	for {
+		tok, lit := p.scan()
+		if tok == EOF {
+			break
+		}
+		values = append(values, lit)
+	}
+
+	if p.limit != nil && p.limit.exceeded {
+		return nil, ErrOversized
+	}
+
+	stmt := &Statement{Version: version}
+	p.assign(stmt, tok, keyLit, splitAndTrimSpace(values))
+	p.linkGoals(stmt)
+	return stmt, nil
+}
+
+// assign sets the field of stmt for the section introduced by key to lit.
+func (p *Parser) assign(stmt *Statement, key Token, keyLit, lit string) {
+	switch key {
+	case TODAY:
+		stmt.Today = StringField{
+			Key:   keyLit,
+			Val:   lit,
+			Valid: lit != "",
+		}
+	case YESTERDAY:
+		stmt.Yesterday = StringField{
+			Key:   keyLit,
+			Val:   lit,
+			Valid: lit != "",
+		}
+
+		if !p.ref.IsZero() {
+			if day, ok := p.resolvePast(keyLit, lit); ok {
+				stmt.Yesterday.Date = &day
 			}
-		case LP:
-			lit := splitAndTrimSpace(values)
+		}
+	case MEETINGS:
+		stmt.Meetings = StringField{
+			Key:   keyLit,
+			Val:   lit,
+			Valid: lit != "",
+		}
+	case BLOCKERS:
+		stmt.Blockers = StringField{
+			Key:   keyLit,
+			Val:   lit,
+			Valid: lit != "",
+		}
+		stmt.Blockers.RoutedTo = p.routes(lit)
+	case GOALS:
+		stmt.Goals = StringField{
+			Key:   keyLit,
+			Val:   lit,
+			Valid: lit != "",
+		}
+	case OOO:
+		stmt.OOO = DateRangeField{Key: keyLit, Lit: lit}
+
+		if !p.ref.IsZero() {
+			from, to, err := resolveRange(lit, p.ref)
+			stmt.OOO.From, stmt.OOO.To, stmt.OOO.Valid = from, to, err == nil
+		}
+	case LP:
+		val, err := isPositive(lit)
+
+		stmt.LP = BoolField{
+			Key:   keyLit,
+			Val:   val,
+			Lit:   lit,
+			Valid: err == nil,
+		}
+	case JIRA:
+		val, err := isPositive(lit)
+
+		stmt.Jira = BoolField{
+			Key:   keyLit,
+			Val:   val,
+			Lit:   lit,
+			Valid: err == nil,
+		}
+	case SECTION:
+		sec := p.sections[normalize(keyLit)]
+
+		switch sec.kind {
+		case checkSection:
 			val, err := isPositive(lit)
 
-			stmt.LP = BoolField{
+			if stmt.Checks == nil {
+				stmt.Checks = map[string]BoolField{}
+			}
+			stmt.Checks[sec.name] = BoolField{
 				Key:   keyLit,
 				Val:   val,
 				Lit:   lit,
 				Valid: err == nil,
 			}
-		case JIRA:
-			lit := splitAndTrimSpace(values)
-			val, err := isPositive(lit)
+		case numberSection:
+			val, unit, err := parseNumber(lit)
 
-			stmt.Jira = BoolField{
+			if stmt.Numbers == nil {
+				stmt.Numbers = map[string]NumberField{}
+			}
+			stmt.Numbers[sec.name] = NumberField{
 				Key:   keyLit,
 				Val:   val,
+				Unit:  unit,
 				Lit:   lit,
 				Valid: err == nil,
 			}
+		case enumSection:
+			val, err := matchEnum(lit, sec.allowed)
+
+			if stmt.Enums == nil {
+				stmt.Enums = map[string]EnumField{}
+			}
+			stmt.Enums[sec.name] = EnumField{
+				Key:     keyLit,
+				Val:     val,
+				Lit:     lit,
+				Allowed: sec.allowed,
+				Valid:   err == nil,
+			}
 		}
 	}
-
-	return stmt, nil
 }
 
+// negative and positive match the words isPositive looks for.
+var (
+	negative = regexp.MustCompile(`.*(no|off|updating|negative).*`)
+	positive = regexp.MustCompile(`.*(done|yes|up\s+to\s+date|ok|1|affirmative|current|updated)`)
+)
+
 // isPositive is a naive attempt at determining
 // if the string representation of a boolean value is true or false.
 func isPositive(s string) (bool, error) {
-	negative := regexp.MustCompile(`.*(no|off|updating|negative).*`)
-	positive := regexp.MustCompile(`.*(done|yes|up\s+to\s+date|ok|1|affirmative|current|updated)`)
-
-	n := negative.Match([]byte(s))
-	p := positive.Match([]byte(s))
+	n := negative.MatchString(s)
+	p := positive.MatchString(s)
 
 	if p && n {
 		return true, errors.New("ambiguous")
@@ -160,6 +400,90 @@ func isPositive(s string) (bool, error) {
 	return p && !n, nil
 }
 
+// number matches a quantity such as "60%", "4.5h", "6 hours" or "3", and
+// the word right after it, which must be a unit.
+var number = regexp.MustCompile(`(?i)^~?\s*(-?\d+(?:[.,]\d+)*)\s*(%|[a-z]+)?`)
+
+// minutes matches the minutes following hours, as in "4h30" or "4h 30min".
+var minutes = regexp.MustCompile(`(?i)^\s*(\d{1,2})\s*(?:m|mins?|minutes?)?\b`)
+
+// numberRange matches the second end of a range such as "60-70%".
+var numberRange = regexp.MustCompile(`^\s*(?:-|–|—|/|to\b)\s*\d`)
+
+// thousands matches a number with thousands separators, such as "1,000".
+var thousands = regexp.MustCompile(`^-?\d{1,3}(?:,\d{3})+(?:\.\d+)?$`)
+
+// units maps the units a number may have to the unit reported, and how
+// many of them make one of the unit reported.
+var units = map[string]struct {
+	unit string
+	per  float64
+}{
+	"": {"", 1}, "%": {"%", 1}, "percent": {"%", 1},
+	"h": {"h", 1}, "hr": {"h", 1}, "hrs": {"h", 1}, "hour": {"h", 1}, "hours": {"h", 1},
+	"m": {"h", 60}, "min": {"h", 60}, "mins": {"h", 60}, "minute": {"h", 60}, "minutes": {"h", 60},
+}
+
+// parseNumber extracts a quantity and its unit from the start of s. Hours
+// and minutes are reported in hours with the "h" unit, and percentages
+// with "%". Text may follow the quantity, as in "60% (out Friday)", but
+// ranges, thousands separators and unknown units are rejected.
+func parseNumber(s string) (float64, string, error) {
+	s = strings.TrimSpace(s)
+	m := number.FindStringSubmatch(s)
+	if m == nil {
+		return 0, "", errors.New("not a number")
+	}
+
+	if thousands.MatchString(m[1]) {
+		return 0, "", errors.New("ambiguous thousands separator")
+	} else if strings.Count(m[1], ",")+strings.Count(m[1], ".") > 1 {
+		return 0, "", errors.New("not a number")
+	}
+	val, err := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+	if err != nil {
+		return 0, "", err
+	}
+
+	u, ok := units[strings.ToLower(m[2])]
+	if !ok {
+		return 0, "", fmt.Errorf("unknown unit %q", m[2])
+	}
+	val /= u.per
+
+	rest := s[len(m[0]):]
+	if u.unit == "h" && u.per == 1 {
+		if mm := minutes.FindStringSubmatch(rest); mm != nil {
+			n, _ := strconv.Atoi(mm[1])
+			val += float64(n) / 60
+			rest = rest[len(mm[0]):]
+		}
+	}
+	if numberRange.MatchString(rest) {
+		return 0, "", errors.New("ranges are not supported")
+	} else if rest != "" && unicode.IsDigit([]rune(rest)[0]) {
+		return 0, "", errors.New("not a number")
+	}
+
+	return val, u.unit, nil
+}
+
+// matchEnum returns the allowed value s refers to, ignoring case.
+// Trailing words are ignored, so "remote today" matches "remote".
+func matchEnum(s string, allowed []string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for _, a := range allowed {
+		if s == strings.ToLower(a) || (len(words) > 0 && words[0] == strings.ToLower(a)) {
+			return a, nil
+		}
+	}
+	return "", errors.New("not allowed")
+}
+
 // scan returns the next token from the underlying scanner.
 // If a token has been unscanned then read that instead.
 func (p *Parser) scan() (tok Token, lit string) {
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/olivoil/standup-parser"
 	"github.com/davecgh/go-spew/spew"
@@ -13,6 +14,7 @@ import (
 func TestParser_ParseStandup(t *testing.T) {
 	var tests = map[string]struct {
 		s    string
+		opts []parser.Option
 		stmt *parser.Statement
 		err  string
 	}{
@@ -281,10 +283,569 @@ Jira: up to date
 				},
 			},
 		},
+
+		"standup with weekly goals": {
+			s: `
+This week:
+- ship halo v2
+- coomo architecture doc
+Today: halo release notes
+`,
+			stmt: &parser.Statement{
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "halo release notes",
+					Valid: true,
+				},
+				Goals: parser.StringField{
+					Key:   "This week",
+					Val:   "- ship halo v2\n- coomo architecture doc",
+					Valid: true,
+				},
+			},
+		},
+
+		"standup with declared checks": {
+			s: `
+Today: halo
+LP: updated
+Timesheet submitted: yes
+- PR reviews: not yet
+`,
+			opts: []parser.Option{
+				parser.WithCheck("timesheet", "Timesheet submitted", "Timesheet"),
+				parser.WithCheck("reviews", "PR reviews", "PR reviews done"),
+			},
+			stmt: &parser.Statement{
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "halo",
+					Valid: true,
+				},
+				LP: parser.BoolField{
+					Key:   "LP",
+					Val:   true,
+					Lit:   "updated",
+					Valid: true,
+				},
+				Checks: map[string]parser.BoolField{
+					"timesheet": {
+						Key:   "Timesheet submitted",
+						Val:   true,
+						Lit:   "yes",
+						Valid: true,
+					},
+					"reviews": {
+						Key:   "- PR reviews",
+						Val:   false,
+						Lit:   "not yet",
+						Valid: true,
+					},
+				},
+			},
+		},
+
+		"declared check without aliases": {
+			s:    `Standup notes read: done`,
+			opts: []parser.Option{parser.WithCheck("Standup notes read")},
+			stmt: &parser.Statement{
+				Checks: map[string]parser.BoolField{
+					"Standup notes read": {
+						Key:   "Standup notes read",
+						Val:   true,
+						Lit:   "done",
+						Valid: true,
+					},
+				},
+			},
+		},
+
+		"standup with declared numbers": {
+			s: `
+Today: halo
+Capacity: 60%
+Focus hours: 4.5 hours
+Interruptions: 3
+Load: unknown
+`,
+			opts: []parser.Option{
+				parser.WithNumber("capacity"),
+				parser.WithNumber("focus", "Focus hours"),
+				parser.WithNumber("interruptions", "Interruptions"),
+				parser.WithNumber("load"),
+			},
+			stmt: &parser.Statement{
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "halo",
+					Valid: true,
+				},
+				Numbers: map[string]parser.NumberField{
+					"capacity": {
+						Key:   "Capacity",
+						Val:   60,
+						Unit:  "%",
+						Lit:   "60%",
+						Valid: true,
+					},
+					"focus": {
+						Key:   "Focus hours",
+						Val:   4.5,
+						Unit:  "h",
+						Lit:   "4.5 hours",
+						Valid: true,
+					},
+					"interruptions": {
+						Key:   "Interruptions",
+						Val:   3,
+						Lit:   "3",
+						Valid: true,
+					},
+					"load": {
+						Key:   "Load",
+						Lit:   "unknown",
+						Valid: false,
+					},
+				},
+			},
+		},
+
+		"standup with an absolute OOO range": {
+			s:    `OOO: May 20–24`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.May, 14))},
+			stmt: &parser.Statement{
+				OOO: parser.DateRangeField{
+					Key:   "OOO",
+					From:  date(2018, time.May, 20),
+					To:    date(2018, time.May, 24),
+					Lit:   "May 20–24",
+					Valid: true,
+				},
+			},
+		},
+
+		"standup with a relative OOO day": {
+			s: `
+Today: halo
+Out: next Thursday
+`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.May, 14))},
+			stmt: &parser.Statement{
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "halo",
+					Valid: true,
+				},
+				OOO: parser.DateRangeField{
+					Key:   "Out",
+					From:  date(2018, time.May, 17),
+					To:    date(2018, time.May, 17),
+					Lit:   "next Thursday",
+					Valid: true,
+				},
+			},
+		},
+
+		"standup with an OOO range across months and years": {
+			s:    `PTO: Dec 28 to Jan 2`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.December, 20))},
+			stmt: &parser.Statement{
+				OOO: parser.DateRangeField{
+					Key:   "PTO",
+					From:  date(2018, time.December, 28),
+					To:    date(2019, time.January, 2),
+					Lit:   "Dec 28 to Jan 2",
+					Valid: true,
+				},
+			},
+		},
+
+		"standup with OOO and no reference time": {
+			s: `OOO: tomorrow`,
+			stmt: &parser.Statement{
+				OOO: parser.DateRangeField{
+					Key: "OOO",
+					Lit: "tomorrow",
+				},
+			},
+		},
+
+		"standup with declared enums": {
+			s: `
+Mood: Green
+Location: remote today
+Status: purple
+`,
+			opts: []parser.Option{
+				parser.WithEnum("mood", []string{"green", "yellow", "red"}),
+				parser.WithEnum("location", []string{"office", "remote"}),
+				parser.WithEnum("status", []string{"green", "yellow", "red"}),
+			},
+			stmt: &parser.Statement{
+				Enums: map[string]parser.EnumField{
+					"mood": {
+						Key:     "Mood",
+						Val:     "green",
+						Lit:     "Green",
+						Allowed: []string{"green", "yellow", "red"},
+						Valid:   true,
+					},
+					"location": {
+						Key:     "Location",
+						Val:     "remote",
+						Lit:     "remote today",
+						Allowed: []string{"office", "remote"},
+						Valid:   true,
+					},
+					"status": {
+						Key:     "Status",
+						Lit:     "purple",
+						Allowed: []string{"green", "yellow", "red"},
+					},
+				},
+			},
+		},
+
+		"yesterday on a monday resolves to friday": {
+			s:    `Yesterday: ibm`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.May, 14))},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Yesterday",
+					Val:   "ibm",
+					Valid: true,
+					Date:  datep(2018, time.May, 11),
+				},
+			},
+		},
+
+		"bare weekday key resolves to the previous such day": {
+			s:    `Friday: ibm`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.May, 14))},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Friday",
+					Val:   "ibm",
+					Valid: true,
+					Date:  datep(2018, time.May, 11),
+				},
+			},
+		},
+
+		"last weekday key": {
+			s:    `Last Thursday: ibm`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.May, 14))},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Last Thursday",
+					Val:   "ibm",
+					Valid: true,
+					Date:  datep(2018, time.May, 10),
+				},
+			},
+		},
+
+		"past day phrase in value": {
+			s:    `Previously: on Wednesday I was out, then halo`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.May, 14))},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Previously",
+					Val:   "on Wednesday I was out, then halo",
+					Valid: true,
+					Date:  datep(2018, time.May, 9),
+				},
+			},
+		},
+
+		"before the long weekend": {
+			s:    `Before the long weekend: halo`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.May, 29))},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Before the long weekend",
+					Val:   "halo",
+					Valid: true,
+					Date:  datep(2018, time.May, 25),
+				},
+			},
+		},
+
+		"previously without a past day phrase": {
+			s:    `Previously: vacation`,
+			opts: []parser.Option{parser.WithReferenceTime(date(2018, time.May, 14))},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Previously",
+					Val:   "vacation",
+					Valid: true,
+				},
+			},
+		},
+
+		"yesterday after a holiday resolves to the last working day": {
+			s: `Yesterday: halo`,
+			opts: []parser.Option{
+				parser.WithReferenceTime(date(2018, time.May, 29)),
+				parser.WithCalendar(parser.USHolidays),
+			},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Yesterday",
+					Val:   "halo",
+					Valid: true,
+					Date:  datep(2018, time.May, 25),
+				},
+			},
+		},
+
+		"before the long weekend with a company day off": {
+			s: `Before the long weekend: halo`,
+			opts: []parser.Option{
+				parser.WithReferenceTime(date(2018, time.August, 21)),
+				parser.WithCalendar(parser.Holidays(date(2018, time.August, 17), date(2018, time.August, 20))),
+			},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Before the long weekend",
+					Val:   "halo",
+					Valid: true,
+					Date:  datep(2018, time.August, 16),
+				},
+			},
+		},
+
+		"yesterday resolves in the poster's time zone": {
+			s: `Yesterday: halo`,
+			opts: []parser.Option{
+				// Tuesday in UTC, still Monday evening in Pacific time.
+				parser.WithReferenceTime(time.Date(2018, time.May, 15, 2, 0, 0, 0, time.UTC)),
+				parser.WithLocation(pacific),
+			},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Yesterday",
+					Val:   "halo",
+					Valid: true,
+					Date:  &fridayPacific,
+				},
+			},
+		},
+
+		"out of office line without a colon": {
+			s: `Out next Thursday
+Today: halo
+- PTO May 20-24`,
+			opts: []parser.Option{parser.WithReferenceTime(time.Date(2018, time.May, 14, 9, 0, 0, 0, time.UTC))},
+			stmt: &parser.Statement{
+				OOO: parser.DateRangeField{
+					Key:   "PTO",
+					From:  date(2018, time.May, 20),
+					To:    date(2018, time.May, 24),
+					Lit:   "May 20-24",
+					Valid: true,
+				},
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "halo",
+					Valid: true,
+				},
+			},
+		},
+
+		"out of office request example": {
+			s:    `Out next Thursday`,
+			opts: []parser.Option{parser.WithReferenceTime(time.Date(2018, time.May, 14, 9, 0, 0, 0, time.UTC))},
+			stmt: &parser.Statement{
+				OOO: parser.DateRangeField{
+					Key:   "Out",
+					From:  date(2018, time.May, 17),
+					To:    date(2018, time.May, 17),
+					Lit:   "next Thursday",
+					Valid: true,
+				},
+			},
+		},
+
+		"out without a date": {
+			s: `Out of ideas for halo`,
+			stmt: &parser.Statement{
+				Today: parser.StringField{
+					Val:   "Out of ideas for halo",
+					Valid: true,
+				},
+			},
+		},
+
+		"voice transcript": {
+			s:    `Um, so yesterday I worked on the halo deployment and the slack bot. Today I'm going to work on coomo architecture planning, and I have meetings with design and the PM team. No blockers. LP is up to date and Jira is done.`,
+			opts: []parser.Option{parser.WithTranscript()},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Yesterday",
+					Val:   "the halo deployment and the slack bot",
+					Valid: true,
+				},
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "coomo architecture planning",
+					Valid: true,
+				},
+				Meetings: parser.StringField{
+					Key:   "Meetings",
+					Val:   "design and the PM team",
+					Valid: true,
+				},
+				Blockers: parser.StringField{
+					Key:   "Blockers",
+					Val:   "none",
+					Valid: true,
+				},
+				LP: parser.BoolField{
+					Key:   "LP",
+					Val:   true,
+					Lit:   "up to date",
+					Valid: true,
+				},
+				Jira: parser.BoolField{
+					Key:   "Jira",
+					Val:   true,
+					Lit:   "done",
+					Valid: true,
+				},
+			},
+		},
+
+		"voice transcript with a blocker": {
+			s:    `Yesterday I finished the release notes. Today I will keep working on billing but I'm blocked on the API keys from the client.`,
+			opts: []parser.Option{parser.WithTranscript()},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "Yesterday",
+					Val:   "the release notes",
+					Valid: true,
+				},
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "billing",
+					Valid: true,
+				},
+				Blockers: parser.StringField{
+					Key:   "Blockers",
+					Val:   "the API keys from the client",
+					Valid: true,
+				},
+			},
+		},
+
+		"voice transcript with time in a sentence": {
+			s:    `Today I will work on halo, the lead time is long so I'll pair with Bob. My timesheet is done.`,
+			opts: []parser.Option{parser.WithTranscript()},
+			stmt: &parser.Statement{
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "halo, the lead time is long so I'll pair with Bob",
+					Valid: true,
+				},
+				LP: parser.BoolField{
+					Key:   "LP",
+					Val:   true,
+					Lit:   "done",
+					Valid: true,
+				},
+			},
+		},
+
+		"implicit section set to yesterday": {
+			s: `
+halo, coomo
+Today: ibm
+`,
+			opts: []parser.Option{parser.WithImplicitSection(parser.YESTERDAY)},
+			stmt: &parser.Statement{
+				Yesterday: parser.StringField{
+					Key:   "",
+					Val:   "halo, coomo",
+					Valid: true,
+				},
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "ibm",
+					Valid: true,
+				},
+			},
+		},
+
+		"implicit section disabled": {
+			s: `
+halo, coomo
+Today: ibm
+`,
+			opts: []parser.Option{parser.WithoutImplicitSection()},
+			err:  `found "halo, coomo", expected a section keyword`,
+		},
+
+		"implicit section disabled with keywords only": {
+			s:    `Today: ibm`,
+			opts: []parser.Option{parser.WithoutImplicitSection()},
+			stmt: &parser.Statement{
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "ibm",
+					Valid: true,
+				},
+			},
+		},
+
+		"blockers routed to people, teams and vendors": {
+			s: `
+Today: halo
+Blockers: waiting on Design for mocks, @bob to review, and the ACME API keys
+`,
+			opts: []parser.Option{
+				parser.WithRoute(parser.Route{Name: "alice", Kind: "person", Channel: "@alice"}),
+				parser.WithRoute(parser.Route{Name: "bob", Kind: "person", Channel: "@bob"}),
+				parser.WithRoute(parser.Route{Name: "design", Kind: "team", Channel: "#design"}, "design", "ux"),
+				parser.WithRoute(parser.Route{Name: "Acme Corp", Kind: "vendor", Channel: "#acme-shared"}, "Acme", "Acme Corp"),
+			},
+			stmt: &parser.Statement{
+				Today: parser.StringField{
+					Key:   "Today",
+					Val:   "halo",
+					Valid: true,
+				},
+				Blockers: parser.StringField{
+					Key:   "Blockers",
+					Val:   "waiting on Design for mocks, @bob to review, and the ACME API keys",
+					Valid: true,
+					RoutedTo: []parser.Route{
+						{Name: "design", Kind: "team", Channel: "#design"},
+						{Name: "bob", Kind: "person", Channel: "@bob"},
+						{Name: "Acme Corp", Kind: "vendor", Channel: "#acme-shared"},
+					},
+				},
+			},
+		},
+
+		"blockers without mentions": {
+			s:    `Blockers: none, designs are done`,
+			opts: []parser.Option{parser.WithRoute(parser.Route{Name: "design", Kind: "team", Channel: "#design"})},
+			stmt: &parser.Statement{
+				Blockers: parser.StringField{
+					Key:   "Blockers",
+					Val:   "none, designs are done",
+					Valid: true,
+				},
+			},
+		},
 	}
 
 	for label, tt := range tests {
-		stmt, err := parser.New(strings.NewReader(tt.s)).Parse()
+		if tt.stmt != nil {
+			tt.stmt.Version = parser.Version()
+		}
+
+		stmt, err := parser.New(strings.NewReader(tt.s), tt.opts...).Parse()
 		if !reflect.DeepEqual(tt.err, errstring(err)) {
 			t.Errorf(
 				"[%v] %q: error mismatch:\n  exp=%s\n  got=%s\n\n",
@@ -299,6 +860,128 @@ Jira: up to date
 	}
 }
 
+// Ensure declared numbers are only valid when the whole quantity is understood.
+func TestParser_Numbers(t *testing.T) {
+	var tests = []struct {
+		lit   string
+		val   float64
+		unit  string
+		valid bool
+	}{
+		{lit: "60%", val: 60, unit: "%", valid: true},
+		{lit: "~3", val: 3, valid: true},
+		{lit: "4,5 hours", val: 4.5, unit: "h", valid: true},
+		{lit: "80% (out Friday)", val: 80, unit: "%", valid: true},
+		{lit: "90 min", val: 1.5, unit: "h", valid: true},
+		{lit: "4h30", val: 4.5, unit: "h", valid: true},
+		{lit: "4h 15min, mostly halo", val: 4.25, unit: "h", valid: true},
+		{lit: "1,000"},
+		{lit: "1.000.000"},
+		{lit: "60-70%"},
+		{lit: "2 to 3 hours"},
+		{lit: "4 days"},
+		{lit: "3 interruptions"},
+		{lit: "lots"},
+	}
+
+	for _, tt := range tests {
+		stmt, err := parser.New(strings.NewReader("Capacity: "+tt.lit), parser.WithNumber("capacity")).Parse()
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.lit, err)
+			continue
+		}
+		exp := parser.NumberField{Key: "Capacity", Lit: tt.lit, Valid: tt.valid}
+		if tt.valid {
+			exp.Val, exp.Unit = tt.val, tt.unit
+		}
+		if got := stmt.Numbers["capacity"]; !reflect.DeepEqual(exp, got) {
+			t.Errorf("%q: mismatch:\n  exp=%+v\n  got=%+v", tt.lit, exp, got)
+		}
+	}
+}
+
+// Ensure ParseSection reads the whole input as the value of one section.
+func TestParser_ParseSection(t *testing.T) {
+	var tests = map[string]struct {
+		s    string
+		key  string
+		opts []parser.Option
+		exp  *parser.Statement
+		err  string
+	}{
+		"keywords in the value": {
+			s:   "- halo\n- meeting\n- time: fix bugs",
+			key: "Today",
+			exp: &parser.Statement{Today: parser.StringField{Key: "Today", Val: "- halo\n- meeting\n- time: fix bugs", Valid: true}},
+		},
+
+		"declared section": {
+			s:    " 60% ",
+			key:  "Capacity",
+			opts: []parser.Option{parser.WithNumber("capacity")},
+			exp: &parser.Statement{Numbers: map[string]parser.NumberField{
+				"capacity": {Key: "Capacity", Val: 60, Unit: "%", Lit: "60%", Valid: true},
+			}},
+		},
+
+		"resolved date": {
+			s:    "PTO",
+			key:  "Friday",
+			opts: []parser.Option{parser.WithReferenceTime(time.Date(2018, time.May, 14, 9, 0, 0, 0, time.UTC))},
+			exp:  &parser.Statement{Yesterday: parser.StringField{Key: "Friday", Val: "PTO", Valid: true, Date: datep(2018, time.May, 11)}},
+		},
+
+		"unknown key": {
+			s:   "yes",
+			key: "Timesheet",
+			err: `"Timesheet" is not a section keyword`,
+		},
+	}
+
+	for label, tt := range tests {
+		if tt.exp != nil {
+			tt.exp.Version = parser.Version()
+		}
+
+		stmt, err := parser.New(strings.NewReader(tt.s), tt.opts...).ParseSection(tt.key)
+		if !reflect.DeepEqual(tt.err, errstring(err)) {
+			t.Errorf("[%v] error mismatch:\n  exp=%s\n  got=%s\n\n", label, tt.err, err)
+		} else if !reflect.DeepEqual(tt.exp, stmt) {
+			t.Errorf("[%v] stmt mismatch:\n\nexp=%v\n\ngot=%v\n\n", label, spew.Sdump(tt.exp), spew.Sdump(stmt))
+		}
+	}
+}
+
+// Ensure invalid enum values are reported.
+func TestEnumField_Err(t *testing.T) {
+	stmt, _ := parser.New(
+		strings.NewReader(`Mood: purple`),
+		parser.WithEnum("mood", []string{"green", "yellow", "red"}),
+	).Parse()
+
+	exp := `"purple" is not one of green, yellow, red`
+	if err := stmt.Enums["mood"].Err(); errstring(err) != exp {
+		t.Errorf("error mismatch:\n  exp=%s\n  got=%s", exp, err)
+	}
+}
+
+// pacific is a fixed Pacific Daylight Time zone.
+var pacific = time.FixedZone("PDT", -7*60*60)
+
+// fridayPacific is midnight on Friday, May 11 2018 in Pacific time.
+var fridayPacific = time.Date(2018, time.May, 11, 0, 0, 0, 0, pacific)
+
+// date returns midnight UTC on the given day.
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// datep returns a pointer to midnight UTC on the given day.
+func datep(year int, month time.Month, day int) *time.Time {
+	t := date(year, month, day)
+	return &t
+}
+
 // errstring returns the string representation of an error.
 func errstring(err error) string {
 	if err != nil {
@@ -0,0 +1,236 @@
+// Package plaintext renders standup statements as plain text, without
+// markdown or emoji, wrapped to a fixed width. It is meant for screen
+// readers and plain-text email clients.
+package plaintext
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Renderer renders statements as plain text.
+type Renderer struct {
+	// Width is the maximum line width. Zero or less disables wrapping.
+	Width int
+}
+
+// New returns a new instance of Renderer wrapping lines at width.
+func New(width int) *Renderer {
+	return &Renderer{Width: width}
+}
+
+// Render writes stmt to w. Sections are written in a fixed order, each as
+// a label line followed by one indented line per item; empty sections are
+// omitted. Check-ins are spelled out as "yes" or "no".
+func (r *Renderer) Render(w io.Writer, stmt *parser.Statement) error {
+	bw := bufio.NewWriter(w)
+
+	// Values the renderer generates, such as numbers and dates, are added
+	// as is; only text written by the user is cleaned up.
+	var sections []section
+	add := func(label, val string) {
+		if val != "" {
+			sections = append(sections, section{label: label, val: val})
+		}
+	}
+	text := func(label, val string) { add(label, clean(val)) }
+
+	str := func(label string, f parser.StringField) {
+		if f.Date != nil {
+			label += " (" + f.Date.Format("Monday, January 2") + ")"
+		}
+		text(label, f.Val)
+	}
+	str("Yesterday", stmt.Yesterday)
+	str("Today", stmt.Today)
+	str("Goals", stmt.Goals)
+	str("Meetings", stmt.Meetings)
+	str("Blockers", stmt.Blockers)
+
+	if f := stmt.OOO; f.Valid && f.From.Equal(f.To) {
+		add("Out of office", f.From.Format("Monday, January 2"))
+	} else if f.Valid {
+		add("Out of office", f.From.Format("Monday, January 2")+" to "+f.To.Format("Monday, January 2"))
+	} else {
+		text("Out of office", f.Lit)
+	}
+
+	check := func(label string, f parser.BoolField) {
+		if f.Key == "" {
+			return
+		}
+		val := "unclear"
+		if f.Valid && f.Val {
+			val = "yes"
+		} else if f.Valid {
+			val = "no"
+		}
+		if lit := clean(f.Lit); lit != "" {
+			val += ", " + lit
+		}
+		add(label, val)
+	}
+	check("LP", stmt.LP)
+	check("Jira", stmt.Jira)
+	for _, name := range sortedKeys(stmt.Checks) {
+		check(name, stmt.Checks[name])
+	}
+
+	for _, name := range sortedKeys(stmt.Numbers) {
+		f := stmt.Numbers[name]
+		if !f.Valid {
+			text(name, f.Lit)
+			continue
+		}
+		val := strconv.FormatFloat(f.Val, 'f', -1, 64)
+		switch f.Unit {
+		case "%":
+			val += " percent"
+		case "h":
+			val += " hours"
+		}
+		add(name, val)
+	}
+
+	for _, name := range sortedKeys(stmt.Enums) {
+		if f := stmt.Enums[name]; f.Valid {
+			add(name, f.Val)
+		} else {
+			text(name, f.Lit)
+		}
+	}
+
+	for i, sec := range sections {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		bw.WriteString(sec.label + ":\n")
+		for _, line := range strings.Split(sec.val, "\n") {
+			for _, l := range wrap(line, r.Width-2) {
+				bw.WriteString("  " + l + "\n")
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// section is a rendered label and its cleaned up value.
+type section struct {
+	label string
+	val   string
+}
+
+// shortcode matches emoji shortcodes such as ":tada:" that stand as a
+// word of their own, so times such as "9:30-10:00" are kept.
+var shortcode = regexp.MustCompile(`(^|\s)(?::[a-z][a-z0-9_+-]*:)+($|[\s.,!?;)])`)
+
+// emphasis matches markdown emphasis wrapping one or several words, such
+// as "*halo*" or "_not yet_", but not the underscores of "config_loader".
+var emphasis []*regexp.Regexp
+
+func init() {
+	for _, m := range []string{"**", "__", "~~", "*", "_", "~", "`"} {
+		q, c := regexp.QuoteMeta(m), regexp.QuoteMeta(m[:1])
+		emphasis = append(emphasis, regexp.MustCompile(
+			`(^|[\s(])`+q+`([^\s`+c+`](?:[^`+c+`]*[^\s`+c+`])?)`+q+`($|[\s.,!?:;)])`))
+	}
+}
+
+// listMarker matches a markdown list marker at the start of a line.
+// Numbered and "*" markers must be followed by a space, so "3.5 hours"
+// and "*halo*" are kept.
+var listMarker = regexp.MustCompile(`^\s*(?:[->•]\s*|(?:[*+]|\d+[.)])(?:\s+|$))`)
+
+// clean strips markdown, list markers and emoji from each line of s,
+// dropping lines left empty.
+func clean(s string) string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		l = listMarker.ReplaceAllString(l, "")
+		l = replaceAll(shortcode, l, "$1$2")
+		for _, re := range emphasis {
+			l = replaceAll(re, l, "$1$2$3")
+		}
+		l = strings.Map(func(r rune) rune {
+			if isEmoji(r) {
+				return -1
+			}
+			return r
+		}, l)
+		if l = strings.Join(strings.Fields(l), " "); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// replaceAll replaces the matches of re in s until there are none left,
+// since adjacent matches share the space between them.
+func replaceAll(re *regexp.Regexp, s, repl string) string {
+	for {
+		r := re.ReplaceAllString(s, repl)
+		if r == s {
+			return r
+		}
+		s = r
+	}
+}
+
+// isEmoji is true if r is an emoji or joins emoji. Other symbols, such as
+// "°" or "™", are kept.
+func isEmoji(r rune) bool {
+	return r >= 0x1F000 ||
+		(r >= 0x2600 && r <= 0x27BF) || // miscellaneous symbols and dingbats
+		(r >= 0x2B00 && r <= 0x2BFF) || // arrows and stars such as "⭐"
+		r == '\uFE0F' || r == '\u200D'
+}
+
+// wrap breaks s into lines of at most width runes, between words.
+// Words longer than width are kept whole.
+func wrap(s string, width int) []string {
+	if width <= 0 {
+		return []string{s}
+	}
+
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(s) {
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return append(lines, line)
+}
+
+// sortedKeys returns the keys of a declared section map in order.
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]parser.BoolField:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]parser.NumberField:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]parser.EnumField:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,123 @@
+package plaintext_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olivoil/standup-parser"
+	"github.com/olivoil/standup-parser/plaintext"
+)
+
+// Ensure statements render as wrapped plain text without markdown or emoji.
+func TestRenderer_Render(t *testing.T) {
+	var tests = map[string]struct {
+		s     string
+		opts  []parser.Option
+		width int
+		exp   string
+	}{
+		"typical statement": {
+			s: `
+Yesterday: *ibm*, slack :tada:
+Today:
+  - halo: finish deployment 🚀
+  - yourtrainer: last issues
+  - blockers: none
+LP: up to date
+Jira: not yet
+Capacity: 60%
+`,
+			opts:  []parser.Option{parser.WithNumber("Capacity")},
+			width: 72,
+			exp: `Yesterday:
+  ibm, slack
+
+Today:
+  halo: finish deployment
+  yourtrainer: last issues
+
+Blockers:
+  none
+
+LP:
+  yes, up to date
+
+Jira:
+  no, not yet
+
+Capacity:
+  60 percent
+`,
+		},
+
+		"wrapping and resolved dates": {
+			s: `
+Friday: ibm
+Today: a long list of things that will not fit on one narrow line at all
+OOO: May 20-24
+`,
+			opts:  []parser.Option{parser.WithReferenceTime(time.Date(2018, time.May, 14, 9, 0, 0, 0, time.UTC))},
+			width: 24,
+			exp: `Yesterday (Friday, May 11):
+  ibm
+
+Today:
+  a long list of things
+  that will not fit on
+  one narrow line at all
+
+Out of office:
+  Sunday, May 20 to
+  Thursday, May 24
+`,
+		},
+
+		"times, identifiers and symbols": {
+			s: `
+Meetings: standup 9:30-10:00, retro 14:00-15:00
+Today: fix snake_case in config_loader, *halo* and __coomo__ :tada: :rocket:
+- ~~drop~~ the 2°C alert™ ✅ ⭐
+`,
+			width: 72,
+			exp: `Today:
+  fix snake_case in config_loader, halo and coomo
+  drop the 2°C alert™
+
+Meetings:
+  standup 9:30-10:00, retro 14:00-15:00
+`,
+		},
+
+		"decimal numbers": {
+			s: `
+Today: 3.5 hours on halo
+1. review
+2) deploy
+Focus: 4.5h
+`,
+			opts:  []parser.Option{parser.WithNumber("Focus")},
+			width: 72,
+			exp: `Today:
+  3.5 hours on halo
+  review
+  deploy
+
+Focus:
+  4.5 hours
+`,
+		},
+	}
+
+	for label, tt := range tests {
+		stmt, _ := parser.New(strings.NewReader(tt.s), tt.opts...).Parse()
+
+		var buf bytes.Buffer
+		if err := plaintext.New(tt.width).Render(&buf, stmt); err != nil {
+			t.Errorf("[%v] unexpected error: %s", label, err)
+		} else if got := buf.String(); got != tt.exp {
+			t.Errorf("[%v] output mismatch:\n\nexp=\n%s\n\ngot=\n%s", label, tt.exp, got)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// Repair describes a fix made to the input before scanning.
+type Repair struct {
+	Offset int    `json:"offset"` // byte offset in the original input
+	Kind   string `json:"kind"`   // "mojibake", "invalid" or "control"
+	Old    string `json:"old"`    // replaced text, possibly invalid UTF-8
+	New    string `json:"new"`
+}
+
+// cp1252 holds the characters Windows-1252 maps bytes 0x80 to 0x9F to.
+// Undefined bytes are left as zero.
+var cp1252 = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
+
+// cp1252Bytes maps the characters of cp1252 back to their byte.
+var cp1252Bytes = map[rune]byte{}
+
+func init() {
+	for i, r := range cp1252 {
+		if r != 0 {
+			cp1252Bytes[r] = byte(0x80 + i)
+		}
+	}
+}
+
+// legacyByte returns the byte a character has in Windows-1252, falling
+// back to Latin-1, or false if it is not encodable.
+func legacyByte(r rune) (byte, bool) {
+	if c, ok := cp1252Bytes[r]; ok {
+		return c, true
+	}
+	if r >= 0x80 && r <= 0xFF {
+		return byte(r), true
+	}
+	return 0, false
+}
+
+// legacyRune returns the character a byte stands for in Windows-1252,
+// falling back to Latin-1, or false if the byte is undefined.
+func legacyRune(c byte) (rune, bool) {
+	if c >= 0x80 && c <= 0x9F {
+		r := cp1252[c-0x80]
+		return r, r != 0
+	}
+	return rune(c), c >= 0xA0
+}
+
+// repair fixes the text of b so it can be scanned:
+//
+//   - UTF-8 text that was decoded as Windows-1252 and encoded again
+//     ("â€™" for "’") is restored;
+//   - bytes that are not valid UTF-8 are read as Windows-1252, or replaced
+//     by U+FFFD when undefined there;
+//   - control characters other than tabs and line breaks are dropped,
+//     since the scanner would stop at a NUL.
+//
+// It returns the fixed text and what was repaired.
+func repair(b []byte) ([]byte, []Repair) {
+	var out bytes.Buffer
+	var repairs []Repair
+
+	for i := 0; i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fixed, ok := legacyRune(b[i])
+			if !ok {
+				fixed = utf8.RuneError
+			}
+			out.WriteRune(fixed)
+			repairs = append(repairs, Repair{Offset: i, Kind: "invalid", Old: string(b[i : i+1]), New: string(fixed)})
+
+		case (r < 0x20 && r != '\t' && r != '\n' && r != '\r') || r == 0x7F:
+			repairs = append(repairs, Repair{Offset: i, Kind: "control", Old: string(r)})
+
+		default:
+			if fixed, n := unmojibake(b[i:]); n > 0 {
+				out.WriteRune(fixed)
+				repairs = append(repairs, Repair{Offset: i, Kind: "mojibake", Old: string(b[i : i+n]), New: string(fixed)})
+				i += n
+				continue
+			}
+			out.Write(b[i : i+size])
+		}
+
+		i += size
+	}
+
+	return out.Bytes(), repairs
+}
+
+// mojibakeLeads maps the characters mojibake usually starts with to the
+// length of the UTF-8 sequence they lead: "Â" and "Ã" for Latin-1
+// letters and symbols, "â" for punctuation such as "’" and "ð" for emoji.
+// Other leads are too common in valid text, as in "é »" with the
+// non-breaking space French puts before "»".
+var mojibakeLeads = map[rune]int{'Â': 2, 'Ã': 2, 'â': 3, 'ð': 4}
+
+// unmojibake checks if b starts with the Windows-1252 reading of a UTF-8
+// encoded character, and returns that character and how many bytes of b
+// it replaces, or 0 if it does not.
+func unmojibake(b []byte) (rune, int) {
+	lead, size := utf8.DecodeRune(b)
+	n, ok := mojibakeLeads[lead]
+	if !ok {
+		return 0, 0
+	}
+
+	seq := []byte{byte(lead)}
+	for j := size; len(seq) < n; {
+		r, size := utf8.DecodeRune(b[j:])
+		c, ok := legacyByte(r)
+		if !ok || c < 0x80 || c > 0xBF {
+			return 0, 0
+		}
+		// The characters after "â" or "ð" come from the Windows-1252
+		// range, never a non-breaking space or Latin-1 punctuation.
+		if len(seq) == 1 && n > 2 && c > 0x9F {
+			return 0, 0
+		}
+		seq = append(seq, c)
+		j += size
+		if len(seq) == n {
+			fixed, fsize := utf8.DecodeRune(seq)
+			if fixed == utf8.RuneError || fsize != n {
+				return 0, 0
+			}
+			return fixed, j
+		}
+	}
+	return 0, 0
+}
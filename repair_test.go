@@ -0,0 +1,74 @@
+package parser_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure the input is repaired before scanning and repairs are reported.
+func TestParser_Repairs(t *testing.T) {
+	var tests = map[string]struct {
+		s       string
+		today   string
+		repairs []parser.Repair
+	}{
+		"mojibake": {
+			s:     "Today: itâ€™s the cafÃ© launch",
+			today: "it’s the café launch",
+			repairs: []parser.Repair{
+				{Offset: 9, Kind: "mojibake", Old: "â€™", New: "’"},
+				{Offset: 26, Kind: "mojibake", Old: "Ã©", New: "é"},
+			},
+		},
+
+		"invalid utf-8": {
+			s:     "Today: it\x92s done\x81",
+			today: "it’s done�",
+			repairs: []parser.Repair{
+				{Offset: 9, Kind: "invalid", Old: "\x92", New: "’"},
+				{Offset: 16, Kind: "invalid", Old: "\x81", New: "�"},
+			},
+		},
+
+		"control characters": {
+			s:     "Today: halo\x00 release",
+			today: "halo release",
+			repairs: []parser.Repair{
+				{Offset: 11, Kind: "control", Old: "\x00"},
+			},
+		},
+
+		"emoji mojibake": {
+			s:     "Today: ship it ðŸš€",
+			today: "ship it 🚀",
+			repairs: []parser.Repair{
+				{Offset: 15, Kind: "mojibake", Old: "ðŸš€", New: "🚀"},
+			},
+		},
+
+		"clean accented text": {
+			s:     "Today: «\u00a0café\u00a0» à Montréal",
+			today: "«\u00a0café\u00a0» à Montréal",
+		},
+
+		"clean input": {
+			s:     "Today: café ☕",
+			today: "café ☕",
+		},
+	}
+
+	for label, tt := range tests {
+		p := parser.New(strings.NewReader(tt.s), parser.WithRepair())
+		stmt, err := p.Parse()
+		if err != nil {
+			t.Errorf("[%v] unexpected error: %s", label, err)
+		} else if stmt.Today.Val != tt.today {
+			t.Errorf("[%v] today mismatch:\n  exp=%q\n  got=%q", label, tt.today, stmt.Today.Val)
+		} else if !reflect.DeepEqual(tt.repairs, p.Repairs()) {
+			t.Errorf("[%v] repairs mismatch:\n  exp=%+v\n  got=%+v", label, tt.repairs, p.Repairs())
+		}
+	}
+}
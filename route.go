@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"regexp"
+	"sort"
+)
+
+// Route is a person, team or vendor a blocker can be routed to.
+type Route struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`    // e.g. "person", "team" or "vendor"
+	Channel string `json:"channel"` // where to notify, e.g. "@alice" or "#design"
+}
+
+// routeAlias matches one alias of a route in blocker text.
+type routeAlias struct {
+	re    *regexp.Regexp
+	route Route
+}
+
+// newRouteAlias returns a routeAlias matching alias as a whole word, ignoring case.
+func newRouteAlias(alias string, r Route) routeAlias {
+	return routeAlias{
+		re:    regexp.MustCompile(`(?i)(?:^|[^\pL\pN@#])[@#]?` + regexp.QuoteMeta(alias) + `(?:$|[^\pL\pN])`),
+		route: r,
+	}
+}
+
+// routes returns the routes mentioned in s, in order of first mention.
+func (p *Parser) routes(s string) []Route {
+	type mention struct {
+		at    int
+		route Route
+	}
+
+	var mentions []mention
+	for _, a := range p.directory {
+		if loc := a.re.FindStringIndex(s); loc != nil {
+			mentions = append(mentions, mention{at: loc[0], route: a.route})
+		}
+	}
+	sort.SliceStable(mentions, func(i, j int) bool { return mentions[i].at < mentions[j].at })
+
+	var routes []Route
+	seen := map[Route]bool{}
+	for _, m := range mentions {
+		if !seen[m.route] {
+			seen[m.route] = true
+			routes = append(routes, m.route)
+		}
+	}
+	return routes
+}
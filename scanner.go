@@ -10,7 +10,8 @@ import (
 
 // Scanner represents a lexical scanner.
 type Scanner struct {
-	r *bufio.Reader
+	r        *bufio.Reader
+	sections map[string]section // declared sections, by normalized keyword
 }
 
 // NewScanner returns a new instance of Scanner.
@@ -87,51 +88,30 @@ func (s *Scanner) scanIdent() (tok Token, lit string) {
 	}
 
 	// If the string matches a keyword then return that keyword.
-	switch strings.TrimSpace(strings.Trim(strings.ToUpper(buf.String()), "_*-+>")) {
-
-	case "TODAY":
-		return TODAY, buf.String()
+	if tok, ok := keywords[normalize(buf.String())]; ok {
+		return tok, buf.String()
+	}
 
-	case "YESTERDAY":
-		return YESTERDAY, buf.String()
-	case "WEEKEND":
-		return YESTERDAY, buf.String()
-	case "WEEK-END":
-		return YESTERDAY, buf.String()
-	case "FRIDAY":
-		return YESTERDAY, buf.String()
-	case "FRIDAY/WEEKEND":
-		return YESTERDAY, buf.String()
-	case "PREVIOUSLY":
-		return YESTERDAY, buf.String()
-	case "PREV":
+	// If the string names a past day then it introduces Yesterday.
+	if pastDayKey.MatchString(normalize(buf.String())) {
 		return YESTERDAY, buf.String()
+	}
 
-	case "MEETING":
-		return MEETINGS, buf.String()
-	case "MEETINGS":
-		return MEETINGS, buf.String()
-
-	case "BLOCKER":
-		return BLOCKERS, buf.String()
-	case "BLOCKERS":
-		return BLOCKERS, buf.String()
-
-	case "TIME":
-		return LP, buf.String()
-	case "HOURS":
-		return LP, buf.String()
-	case "LP":
-		return LP, buf.String()
-
-	case "JIRA":
-		return JIRA, buf.String()
+	// If the string matches a declared section then return it.
+	if _, ok := s.sections[normalize(buf.String())]; ok {
+		return SECTION, buf.String()
 	}
 
 	// Otherwise return as a regular identifier.
 	return IDENT, buf.String()
 }
 
+// normalize strips list markers and emphasis from a literal
+// and upper-cases it so it can be matched against keywords.
+func normalize(lit string) string {
+	return strings.TrimSpace(strings.Trim(strings.ToUpper(lit), "_*-+>"))
+}
+
 // read reads the next rune from the bufferred reader.
 // Returns the rune(0) if an error occurs (or io.EOF is returned).
 func (s *Scanner) read() rune {
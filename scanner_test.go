@@ -32,10 +32,16 @@ func TestScanner_Scan(t *testing.T) {
 		{s: `Yesterday`, tok: parser.YESTERDAY, lit: "Yesterday"},
 		{s: `Friday`, tok: parser.YESTERDAY, lit: "Friday"},
 		{s: `Friday/weekend`, tok: parser.YESTERDAY, lit: "Friday/weekend"},
+		{s: `Last Friday`, tok: parser.YESTERDAY, lit: "Last Friday"},
+		{s: `Before the long weekend:`, tok: parser.YESTERDAY, lit: "Before the long weekend"},
 		{s: `meetings`, tok: parser.MEETINGS, lit: "meetings"},
 		{s: `meetings:`, tok: parser.MEETINGS, lit: "meetings"},
 		{s: `- meetings: hello`, tok: parser.MEETINGS, lit: "- meetings"},
 		{s: `blockers`, tok: parser.BLOCKERS, lit: "blockers"},
+		{s: `Goals`, tok: parser.GOALS, lit: "Goals"},
+		{s: `This week:`, tok: parser.GOALS, lit: "This week"},
+		{s: `OOO`, tok: parser.OOO, lit: "OOO"},
+		{s: `Out of office:`, tok: parser.OOO, lit: "Out of office"},
 		{s: `LP`, tok: parser.LP, lit: "LP"},
 		{s: `Jira`, tok: parser.JIRA, lit: "Jira"},
 	}
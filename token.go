@@ -1,5 +1,7 @@
 package parser
 
+import "strconv"
+
 // Token represents a lexical token.
 type Token int
 
@@ -19,6 +21,9 @@ const (
 	BLOCKERS
 	LP
 	JIRA
+	GOALS
+	OOO
+	SECTION // declared with a parser Option
 )
 
 // isKeyword is true if the Token `t` is a keyword.
@@ -27,6 +32,68 @@ func isKeyword(t Token) bool {
 		t == YESTERDAY ||
 		t == MEETINGS ||
 		t == BLOCKERS ||
+		t == GOALS ||
+		t == OOO ||
 		t == LP ||
-		t == JIRA
+		t == JIRA ||
+		t == SECTION
+}
+
+// keywords maps each normalized keyword to the section it introduces.
+var keywords = map[string]Token{
+	"TODAY": TODAY,
+
+	"YESTERDAY":      YESTERDAY,
+	"WEEKEND":        YESTERDAY,
+	"WEEK-END":       YESTERDAY,
+	"FRIDAY":         YESTERDAY,
+	"FRIDAY/WEEKEND": YESTERDAY,
+	"PREVIOUSLY":     YESTERDAY,
+	"PREV":           YESTERDAY,
+
+	"MEETING":  MEETINGS,
+	"MEETINGS": MEETINGS,
+
+	"BLOCKER":  BLOCKERS,
+	"BLOCKERS": BLOCKERS,
+
+	"GOAL":      GOALS,
+	"GOALS":     GOALS,
+	"THIS WEEK": GOALS,
+
+	"OOO":           OOO,
+	"OUT":           OOO,
+	"OUT OF OFFICE": OOO,
+	"PTO":           OOO,
+
+	"TIME":  LP,
+	"HOURS": LP,
+	"LP":    LP,
+
+	"JIRA": JIRA,
+}
+
+// tokens holds the name of each Token.
+var tokens = [...]string{
+	EOF:       "EOF",
+	WS:        "WS",
+	COLON:     "COLON",
+	IDENT:     "IDENT",
+	TODAY:     "TODAY",
+	YESTERDAY: "YESTERDAY",
+	MEETINGS:  "MEETINGS",
+	BLOCKERS:  "BLOCKERS",
+	GOALS:     "GOALS",
+	OOO:       "OOO",
+	LP:        "LP",
+	JIRA:      "JIRA",
+	SECTION:   "SECTION",
+}
+
+// String returns the name of the Token.
+func (t Token) String() string {
+	if t >= 0 && int(t) < len(tokens) {
+		return tokens[t]
+	}
+	return "Token(" + strconv.Itoa(int(t)) + ")"
 }
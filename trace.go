@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// Trace is an anonymized record of how a statement was tokenized, kept
+// when a user flags the statement as misparsed. Section keys, colons and
+// whitespace are kept as is; every other letter is replaced by "x" (or
+// "X") and every digit by "9", so the shape of the message survives but
+// its content does not.
+type Trace struct {
+	Tokens []TraceToken `json:"tokens"`
+
+	// Fields lists the fields users corrected, e.g. "today" or "lp".
+	Fields []string `json:"fields,omitempty"`
+}
+
+// TraceToken is one anonymized token of a Trace.
+type TraceToken struct {
+	Tok string `json:"tok"`
+	Lit string `json:"lit"`
+}
+
+// NewTrace tokenizes raw with the given options and returns its
+// anonymized trace, along with the fields the corrections targeted.
+func NewTrace(raw string, corrections []Correction, opts ...Option) Trace {
+	p := New(strings.NewReader(raw), opts...)
+
+	var t Trace
+	for {
+		tok, lit := p.s.Scan()
+		if tok == EOF {
+			break
+		}
+		if tok == IDENT {
+			lit = anonymize(lit)
+		}
+		t.Tokens = append(t.Tokens, TraceToken{Tok: tok.String(), Lit: lit})
+	}
+
+	for _, c := range corrections {
+		t.Fields = append(t.Fields, c.Field)
+	}
+	return t
+}
+
+// anonymize replaces letters and digits in s, keeping its punctuation.
+func anonymize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsUpper(r):
+			return 'X'
+		case unicode.IsLetter(r):
+			return 'x'
+		case unicode.IsDigit(r):
+			return '9'
+		}
+		return r
+	}, s)
+}
+
+// Corpus collects traces of misparsed statements. Nothing is collected
+// unless the caller opts in by writing traces to a Corpus.
+type Corpus interface {
+	Write(t Trace) error
+}
+
+// DirCorpus is a Corpus that writes each trace as a JSON file in a
+// directory. Files are named after their content, so a trace seen
+// several times is only stored once.
+type DirCorpus string
+
+// Write stores t in the directory.
+func (dir DirCorpus) Write(t Trace) error {
+	b, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(b)
+	name := filepath.Join(string(dir), hex.EncodeToString(sum[:8])+".json")
+	return ioutil.WriteFile(name, b, 0644)
+}
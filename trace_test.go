@@ -0,0 +1,58 @@
+package parser_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure traces keep the structure of a statement but not its content.
+func TestNewTrace(t *testing.T) {
+	raw := "Friday: IBM, Knod 2\nTimesheet: yes"
+	c := []parser.Correction{{Field: "checks.timesheet", Raw: "no", By: "alice"}}
+
+	trace := parser.NewTrace(raw, c, parser.WithCheck("timesheet"))
+
+	exp := parser.Trace{
+		Tokens: []parser.TraceToken{
+			{Tok: "YESTERDAY", Lit: "Friday"},
+			{Tok: "COLON", Lit: ":"},
+			{Tok: "WS", Lit: " "},
+			{Tok: "IDENT", Lit: "XXX, Xxxx 9"},
+			{Tok: "WS", Lit: "\n"},
+			{Tok: "SECTION", Lit: "Timesheet"},
+			{Tok: "COLON", Lit: ":"},
+			{Tok: "WS", Lit: " "},
+			{Tok: "IDENT", Lit: "xxx"},
+		},
+		Fields: []string{"checks.timesheet"},
+	}
+	if !reflect.DeepEqual(exp, trace) {
+		t.Errorf("trace mismatch:\n\nexp=%#v\n\ngot=%#v", exp, trace)
+	}
+}
+
+// Ensure a directory corpus stores identical traces once.
+func TestDirCorpus_Write(t *testing.T) {
+	dir, err := ioutil.TempDir("", "standup-corpus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	trace := parser.NewTrace("Today: halo", nil)
+	for i := 0; i < 2; i++ {
+		if err := parser.DirCorpus(dir).Write(trace); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Errorf("expected 1 trace file, got %d", len(files))
+	}
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+// cueLead matches the filler and punctuation before a spoken cue, so it
+// does not end up in the previous section.
+const cueLead = `(?i)(?:[\s,.;!?]*\b(?:and|but|then|so|also|um|uh|okay|ok|well)\b)*[\s,.;!?]*`
+
+// sentenceStart anchors a cue to the start of a line or to punctuation,
+// for cues whose words are also common in the middle of a sentence.
+const sentenceStart = `(?im)(?:^|[,.;!?])`
+
+// cues maps phrases that open a section in a transcript to the text the
+// section starts with once rewritten.
+var cues = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(cueLead + `\byesterday,?\s+i(?:\s+(?:mostly\s+)?(?:worked on|was working on|did|finished|spent time on|was on|was))?\b`), "\nYesterday: "},
+	{regexp.MustCompile(cueLead + `\btoday,?\s+i(?:'ll|\s+will|'m going to|\s+am going to|\s+plan to|\s+need to)(?:\s+(?:work on|be working on|keep working on|continue|focus on))?\b`), "\nToday: "},
+	{regexp.MustCompile(cueLead + `\b(?:no blockers|nothing(?:'s|\s+is)?\s+blocking(?:\s+me)?|i(?:'m|\s+am)\s+not\s+blocked)\b`), "\nBlockers: none\n"},
+	{regexp.MustCompile(cueLead + `\b(?:(?:my\s+)?blockers?\s+(?:is|are)|i(?:'m|\s+am)\s+blocked\s+(?:on|by))\b`), "\nBlockers: "},
+	{regexp.MustCompile(cueLead + `\b(?:i\s+have|i've\s+got)(?:\s+a|\s+some)?\s+meetings?(?:\s+today)?(?:\s+with)?\b`), "\nMeetings: "},
+	{regexp.MustCompile(sentenceStart + cueLead + `\b(?:my\s+)?(?:lp|timesheets?)\s+(?:is|are)\b`), "\nLP: "},
+	{regexp.MustCompile(cueLead + `\bjira\s+(?:is|are)\b`), "\nJira: "},
+}
+
+// rewriteTranscript reads a speech-to-text transcript and returns it with
+// each spoken cue, e.g. "yesterday I worked on", replaced by a section key.
+func rewriteTranscript(r io.Reader) (io.Reader, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := string(b)
+	for _, cue := range cues {
+		s = cue.re.ReplaceAllString(s, cue.repl)
+	}
+
+	// Sentences end with punctuation rather than line breaks.
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(strings.TrimSpace(l), ".,;")
+	}
+	return strings.NewReader(strings.Join(lines, "\n")), nil
+}
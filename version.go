@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// version is the version of the parser. Bump it whenever a change makes
+// the same input parse differently, so stored statements can be re-parsed.
+const version = "0.1.0"
+
+// Version returns the version of the parser.
+func Version() string { return version }
+
+// CapabilitySet describes what a parser configured with a set of options recognizes.
+type CapabilitySet struct {
+	Version   string   `json:"version"`
+	Languages []string `json:"languages"`
+
+	// Keywords lists the normalized keywords of each field, by field name
+	// as used in Correction.Field, e.g. "today" or "checks.timesheet".
+	Keywords map[string][]string `json:"keywords"`
+
+	// Options lists the options that are enabled, e.g. "WithCalendar".
+	Options []string `json:"options"`
+}
+
+// fields maps each section token to the JSON name of its Statement field.
+var fields = map[Token]string{
+	TODAY:     "today",
+	YESTERDAY: "yesterday",
+	MEETINGS:  "meetings",
+	BLOCKERS:  "blockers",
+	GOALS:     "goals",
+	OOO:       "ooo",
+	LP:        "lp",
+	JIRA:      "jira",
+}
+
+// sectionFields maps each kind of declared section to the JSON name of its Statement field.
+var sectionFields = map[sectionKind]string{
+	checkSection:  "checks",
+	numberSection: "numbers",
+	enumSection:   "enums",
+}
+
+// sectionOptions maps each kind of declared section to the Option declaring it.
+var sectionOptions = map[sectionKind]string{
+	checkSection:  "WithCheck",
+	numberSection: "WithNumber",
+	enumSection:   "WithEnum",
+}
+
+// Capabilities returns the capabilities of a parser configured with opts.
+func Capabilities(opts ...Option) CapabilitySet {
+	p := New(strings.NewReader(""), opts...)
+
+	c := CapabilitySet{
+		Version:   version,
+		Languages: []string{"en"},
+		Keywords:  map[string][]string{},
+	}
+
+	for kw, tok := range keywords {
+		c.Keywords[fields[tok]] = append(c.Keywords[fields[tok]], kw)
+	}
+
+	enabled := map[string]bool{}
+	for kw, sec := range p.sections {
+		name := sec.field()
+		c.Keywords[name] = append(c.Keywords[name], kw)
+		enabled[sectionOptions[sec.kind]] = true
+	}
+	for _, kws := range c.Keywords {
+		sort.Strings(kws)
+	}
+
+	enabled["WithReferenceTime"] = !p.ref.IsZero()
+	enabled["WithLocation"] = p.loc != nil
+	enabled["WithCalendar"] = p.calendar != nil
+	enabled["WithTranscript"] = p.transcript
+	enabled["WithRepair"] = p.repair
+	enabled["WithMaxSize"] = p.maxSize > 0
+	enabled["WithRoute"] = len(p.directory) > 0
+	enabled["WithGoals"] = len(p.goals) > 0
+	enabled["WithImplicitSection"] = p.implicit != TODAY && p.implicit != EOF
+	enabled["WithoutImplicitSection"] = p.implicit == EOF
+	for opt, ok := range enabled {
+		if ok {
+			c.Options = append(c.Options, opt)
+		}
+	}
+	sort.Strings(c.Options)
+
+	return c
+}
@@ -0,0 +1,56 @@
+package parser_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/olivoil/standup-parser"
+)
+
+// Ensure capabilities report keywords, declared sections and enabled options.
+func TestCapabilities(t *testing.T) {
+	c := parser.Capabilities(
+		parser.WithCheck("timesheet", "Timesheet submitted", "Timesheet"),
+		parser.WithReferenceTime(time.Now()),
+		parser.WithCalendar(parser.USHolidays),
+	)
+
+	if c.Version != parser.Version() {
+		t.Errorf("version mismatch: exp=%s got=%s", parser.Version(), c.Version)
+	}
+	if exp := []string{"en"}; !reflect.DeepEqual(exp, c.Languages) {
+		t.Errorf("languages mismatch: exp=%v got=%v", exp, c.Languages)
+	}
+	if exp := []string{"JIRA"}; !reflect.DeepEqual(exp, c.Keywords["jira"]) {
+		t.Errorf("jira keywords mismatch: exp=%v got=%v", exp, c.Keywords["jira"])
+	}
+	if exp := []string{"TIMESHEET", "TIMESHEET SUBMITTED"}; !reflect.DeepEqual(exp, c.Keywords["checks.timesheet"]) {
+		t.Errorf("check keywords mismatch: exp=%v got=%v", exp, c.Keywords["checks.timesheet"])
+	}
+	if exp := []string{"WithCalendar", "WithCheck", "WithReferenceTime"}; !reflect.DeepEqual(exp, c.Options) {
+		t.Errorf("options mismatch: exp=%v got=%v", exp, c.Options)
+	}
+}
+
+// Ensure parsed statements carry the parser version, and stored
+// statements keep the version they were produced with.
+func TestStatement_Version(t *testing.T) {
+	stmt, _ := parser.New(strings.NewReader(`Today: halo`)).Parse()
+	if stmt.Version != parser.Version() {
+		t.Errorf("version mismatch: exp=%s got=%s", parser.Version(), stmt.Version)
+	}
+
+	var decoded parser.Statement
+	if err := json.Unmarshal([]byte(`{"today":{"key":"Today","val":"halo","valid":true}}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	} else if !strings.Contains(string(b), `"version":""`) {
+		t.Errorf("expected a legacy statement to keep its empty version: %s", b)
+	}
+}